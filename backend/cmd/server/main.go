@@ -17,6 +17,7 @@ import (
 	"github.com/auctionapp/backend/internal/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	recovermw "github.com/gofiber/fiber/v2/middleware/recover"
 	fiberws "github.com/gofiber/websocket/v2"
@@ -53,6 +54,7 @@ func main() {
 
 	// Initialize WebSocket hub
 	hub := websocket.NewHub()
+	hub.EnableFanout(redisClient)
 	go func() {
 		// Add panic recovery to hub goroutine
 		defer func() {
@@ -65,6 +67,34 @@ func main() {
 		hub.Run()
 	}()
 
+	// Relay broadcasts between backend nodes over Redis pub/sub
+	fanoutCtx, stopFanout := context.WithCancel(context.Background())
+	defer stopFanout()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("CRITICAL: WebSocket fan-out panic recovered: %v", r)
+			}
+		}()
+		hub.RunFanout(fanoutCtx)
+	}()
+
+	// Drive every auction's lot countdown (only the instance holding an auction's Redis
+	// lease fires its expiry)
+	timerCtx, stopTimer := context.WithCancel(context.Background())
+	defer stopTimer()
+	timerDone := make(chan struct{})
+	go func() {
+		defer close(timerDone)
+		// Panic recovery for the timer loop
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("CRITICAL: Auction timers panic recovered: %v", r)
+			}
+		}()
+		svc.RunTimers(timerCtx)
+	}()
+
 	// Start database connection health monitoring
 	go func() {
 		// Panic recovery for health monitoring goroutine
@@ -119,7 +149,7 @@ func main() {
 	// Middleware
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, Last-Event-ID, X-Overlay-Token",
 		AllowMethods:     "GET, POST, PUT, DELETE, OPTIONS",
 		AllowCredentials: true,
 	}))
@@ -145,16 +175,18 @@ func main() {
 	api.Post("/auth/login", middleware.LoginRateLimit(redisClient), h.Login)
 	api.Post("/auth/refresh", h.RefreshToken)
 
-	// Public read-only routes (for auctions page)
-	api.Get("/public/teams", h.GetTeams)
-	api.Get("/public/teams/:id", h.GetTeam)
-	api.Get("/public/teams/:id/squad", h.GetTeamSquad)
-	api.Get("/public/players", h.GetPlayers)
-	api.Get("/public/players/queue", h.GetPlayerQueue)
-	api.Get("/public/auction/state", h.GetAuctionState)
-	api.Get("/public/stats/top-buys", h.GetTopBuys)
-	api.Get("/public/stats/recent-sales", h.GetRecentSales)
-	api.Get("/public/stream-url", h.GetStreamUrl)
+	// Auctions (a men's league, a women's league, a U-19 league...). Every auction route is
+	// served under /auctions/:auctionId and, for the auction named by ?auction= or else the
+	// default one, at its original path. Routes are matched in order, so the scoped and
+	// global routes are registered before the catch-all legacy groups.
+	api.Get("/public/auctions", h.GetAuctions)
+	registerPublicAuctionRoutes(api.Group("/public/auctions/:auctionId", h.AuctionScope), h)
+	registerPublicAuctionRoutes(api.Group("/public", h.AuctionScope), h)
+
+	// Broadcast overlay routes (OBS/vMix browser sources), guarded by the overlay token of
+	// the auction they resolve to
+	registerOverlayRoutes(api.Group("/auctions/:auctionId/overlay", h.AuctionScope, middleware.OverlayToken(h.OverlayTokenOf)), h)
+	registerOverlayRoutes(api.Group("/overlay", h.AuctionScope, middleware.OverlayToken(h.OverlayTokenOf)), h)
 
 	// Protected routes
 	protected := api.Group("", middleware.JWTAuth(cfg.JWTSecret, redisClient))
@@ -162,75 +194,58 @@ func main() {
 	// Auth
 	protected.Get("/auth/me", h.GetCurrentUser)
 	protected.Post("/auth/logout", h.Logout)
+	protected.Post("/auth/ws-ticket", h.IssueWSTicket) // Single-use ticket for /ws
 	protected.Post("/auth/change-password", h.ChangePassword)
 
-	// Teams
-	protected.Get("/teams", h.GetTeams)
-	protected.Get("/teams/:id", h.GetTeam)
-	protected.Get("/teams/:id/squad", h.GetTeamSquad)
-	protected.Get("/teams/:id/retained", h.GetRetainedPlayers)
-	protected.Post("/teams", middleware.RequireRole("admin", "super_admin"), h.CreateTeam)
-	protected.Put("/teams/:id", middleware.RequireRole("admin", "super_admin"), h.UpdateTeam)
-	protected.Post("/teams/:id/retain", middleware.RequireRole("admin", "super_admin"), h.RetainPlayers)
-	protected.Delete("/teams/:id", middleware.RequireRole("super_admin"), h.DeleteTeam)
+	// Organisations (platform super admins only, apart from a user's own)
+	protected.Get("/organization", h.GetCurrentOrganization)
+	protected.Get("/organizations", middleware.RequirePlatformAdmin, h.GetOrganizations)
+	protected.Post("/organizations", middleware.RequirePlatformAdmin, h.CreateOrganization)
+	protected.Get("/organizations/:id", middleware.RequirePlatformAdmin, h.GetOrganization)
+	protected.Put("/organizations/:id", middleware.RequirePlatformAdmin, h.UpdateOrganization)
+	protected.Delete("/organizations/:id", middleware.RequirePlatformAdmin, h.DeleteOrganization)
 
-	// Players (note: specific routes must come before parameterized routes)
-	protected.Get("/players", h.GetPlayers)
-	protected.Get("/players/queue", h.GetPlayerQueue)
-	protected.Get("/players/:id", h.GetPlayer)
-	protected.Post("/players", middleware.RequireRole("admin", "super_admin"), h.CreatePlayer)
-	protected.Post("/players/import", middleware.RequireRole("admin", "super_admin"), h.ImportPlayers)
-	protected.Put("/players/:id", middleware.RequireRole("admin", "super_admin"), h.UpdatePlayer)
-	protected.Delete("/players/:id", middleware.RequireRole("super_admin"), h.DeletePlayer)
-
-	// Users
+	// Users (org admins and super admins also pass every admin check below, an org admin
+	// only within its organisation)
 	protected.Get("/users", middleware.RequireRole("admin", "super_admin"), h.GetUsers)
 	protected.Get("/users/:id", middleware.RequireRole("admin", "super_admin"), h.GetUser)
 	protected.Post("/users", middleware.RequireRole("admin", "super_admin"), h.CreateUser)
 	protected.Put("/users/:id", middleware.RequireRole("admin", "super_admin"), h.UpdateUser)
 	protected.Delete("/users/:id", middleware.RequireRole("super_admin"), h.DeleteUser)
+	protected.Delete("/users/:id/connections", middleware.RequireRole("admin", "super_admin"), h.DisconnectUserConnections)
 
-	// Auction
-	protected.Get("/auction/state", h.GetAuctionState)
-	protected.Post("/auction/start", middleware.RequireRole("host", "admin", "super_admin"), h.StartAuction)
-	protected.Post("/auction/end", middleware.RequireRole("host", "admin", "super_admin"), h.EndAuction)
-	protected.Post("/auction/pause", middleware.RequireRole("host", "admin", "super_admin"), h.PauseAuction)
-	protected.Post("/auction/resume", middleware.RequireRole("host", "admin", "super_admin"), h.ResumeAuction)
-	protected.Post("/auction/next-player", middleware.RequireRole("host", "admin", "super_admin"), h.NextPlayer)
-	protected.Post("/auction/start-player/:playerId", middleware.RequireRole("host", "admin", "super_admin"), h.StartBidForPlayer)
-	protected.Post("/auction/sell", middleware.RequireRole("host", "admin", "super_admin"), h.SellPlayer)
-	protected.Post("/auction/sell-to-team/:teamId", middleware.RequireRole("host", "admin", "super_admin"), h.SellToTeam) // Manual tie-breaking
-	protected.Post("/auction/unsold", middleware.RequireRole("host", "admin", "super_admin"), h.MarkUnsold)
-	protected.Post("/auction/skip-player", middleware.RequireRole("host", "admin", "super_admin"), h.SkipPlayer)
-	protected.Post("/auction/reset-timer", middleware.RequireRole("host", "admin", "super_admin"), h.ResetTimer)
-	protected.Post("/auction/undo-bid", middleware.RequireRole("host", "admin", "super_admin"), h.UndoBid)
-	protected.Post("/auction/reset", middleware.RequireRole("super_admin"), h.ResetAuction)           // Full reset
-	protected.Post("/auction/reset-everything", middleware.RequireRole("super_admin"), h.ResetEverything) // Complete system reset
-	protected.Post("/auction/broadcast-live", middleware.RequireRole("host", "admin", "super_admin"), h.BroadcastLive) // Go live
-	protected.Post("/auction/stream-url", middleware.RequireRole("host", "admin", "super_admin"), h.SetStreamUrl) // Set YouTube stream URL
-	protected.Post("/auction/toggle-bidder-bidding", middleware.RequireRole("host", "admin", "super_admin"), h.ToggleBidderBidding) // Toggle bidder bidding disabled
-
-	// Bids
-	protected.Post("/bids", middleware.RequireRole("bidder"), h.PlaceBid)
-	protected.Post("/bids/for-team", middleware.RequireRole("host", "admin", "super_admin"), h.PlaceBidForTeam)
-	protected.Get("/bids/history/:playerId", h.GetBidHistory)
-
-	// Settings
-	protected.Get("/settings", middleware.RequireRole("admin", "super_admin"), h.GetSettings)
-	protected.Put("/settings", middleware.RequireRole("super_admin"), h.UpdateSettings)
-
-	// Stats
-	protected.Get("/stats/overview", h.GetOverviewStats)
-	protected.Get("/stats/top-buys", h.GetTopBuys)
-	protected.Get("/stats/recent-sales", h.GetRecentSales)
+	// Live connection routes
+	protected.Get("/connections", middleware.RequireRole("admin", "super_admin"), h.GetConnections)
+	protected.Delete("/connections/:id", middleware.RequireRole("admin", "super_admin"), h.DisconnectConnection)
 
 	// Uploads (admin only)
 	protected.Post("/uploads/image", middleware.RequireRole("admin", "super_admin"), h.UploadImage)
 
+	// Auction management
+	protected.Get("/auctions", h.GetAuctions)
+	protected.Post("/auctions", middleware.RequireRole("admin", "super_admin"), h.CreateAuction)
+	protected.Get("/auctions/:auctionId", h.GetAuction)
+	protected.Put("/auctions/:auctionId", middleware.RequireRole("admin", "super_admin"), h.UpdateAuction)
+	protected.Delete("/auctions/:auctionId", middleware.RequireRole("super_admin"), h.DeleteAuction)
+	// Not under .../overlay, whose token check matches every path with that prefix
+	protected.Get("/auctions/:auctionId/broadcast-token", middleware.RequireRole("admin", "super_admin"), h.GetOverlayToken)
+	protected.Post("/auctions/:auctionId/broadcast-token", middleware.RequireRole("admin", "super_admin"), h.RotateOverlayToken)
+
+	// Season archives (past seasons of the organisation's auctions)
+	protected.Get("/archives", h.GetArchives)
+	protected.Get("/archives/players/history", h.GetArchivedPlayerHistory) // ?name=&country=
+	protected.Get("/archives/:id", h.GetArchive)
+	protected.Get("/archives/:id/bids", h.GetArchiveBids)
+
+	registerAuctionRoutes(protected.Group("/auctions/:auctionId", h.AuctionScope), h)
+	registerAuctionRoutes(protected.Group("", h.AuctionScope), h)
+
 	// Public WebSocket endpoint (no auth required - for public auction viewers)
-	// Uses /ws-public to avoid path prefix conflict with /ws
+	// Uses /ws-public to avoid path prefix conflict with /ws. Every socket and stream
+	// follows the auction named by ?auction=, else the default (or the bidder's team's) one.
 	app.Use("/ws-public", func(c *fiber.Ctx) error {
 		if fiberws.IsWebSocketUpgrade(c) {
+			c.Locals("ip", c.IP()) // shown to admins listing connections
 			return c.Next()
 		}
 		return fiber.ErrUpgradeRequired
@@ -239,9 +254,16 @@ func main() {
 		websocket.HandlePublicWebSocket(c, hub, svc)
 	}))
 
+	// Server-Sent Events stream of the public WebSocket events (for proxies that block
+	// WebSockets, OBS browser sources and simple embeds)
+	app.Get("/sse-public", func(c *fiber.Ctx) error {
+		return websocket.HandlePublicSSE(c, hub, svc)
+	})
+
 	// WebSocket endpoint (authenticated)
 	app.Use("/ws", func(c *fiber.Ctx) error {
 		if fiberws.IsWebSocketUpgrade(c) {
+			c.Locals("ip", c.IP()) // shown to admins listing connections
 			return c.Next()
 		}
 		return fiber.ErrUpgradeRequired
@@ -286,6 +308,11 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Release the timer lease so another instance can take over immediately
+	stopTimer()
+	<-timerDone
+	stopFanout()
+
 	// Close database connections
 	log.Println("Closing database connections...")
 	db.Close()
@@ -296,3 +323,91 @@ func main() {
 
 	log.Println("Server shutdown complete")
 }
+
+// registerPublicAuctionRoutes registers the read-only routes of one auction
+func registerPublicAuctionRoutes(r fiber.Router, h *handlers.Handlers) {
+	r.Get("/teams", h.GetTeams)
+	r.Get("/teams/:id", h.GetTeam)
+	r.Get("/teams/:id/squad", h.GetTeamSquad)
+	r.Get("/players", h.GetPlayers)
+	r.Get("/players/queue", h.GetPlayerQueue)
+	r.Get("/auction/state", etag.New(), h.GetAuctionState)
+	r.Get("/stats/top-buys", h.GetTopBuys)
+	r.Get("/stats/recent-sales", h.GetRecentSales)
+	r.Get("/stream-url", h.GetStreamUrl)
+}
+
+// registerOverlayRoutes registers the broadcast overlay routes of one auction
+func registerOverlayRoutes(r fiber.Router, h *handlers.Handlers) {
+	r.Get("/state", h.GetOverlayState)
+	r.Get("/events", h.StreamOverlayEvents)
+	r.Get("/:graphic", h.RenderOverlay) // all, lot, ticker, sold, purses, next-up
+}
+
+// registerAuctionRoutes registers the authenticated routes of one auction
+func registerAuctionRoutes(r fiber.Router, h *handlers.Handlers) {
+	// Teams
+	r.Get("/teams", h.GetTeams)
+	r.Get("/teams/:id", h.GetTeam)
+	r.Get("/teams/:id/squad", h.GetTeamSquad)
+	r.Get("/teams/:id/retained", h.GetRetainedPlayers)
+	r.Post("/teams", middleware.RequireRole("admin", "super_admin"), h.CreateTeam)
+	r.Put("/teams/:id", middleware.RequireRole("admin", "super_admin"), h.UpdateTeam)
+	r.Post("/teams/:id/retain", middleware.RequireRole("admin", "super_admin"), h.RetainPlayers)
+	r.Delete("/teams/:id", middleware.RequireRole("super_admin"), h.DeleteTeam)
+
+	// Players (note: specific routes must come before parameterized routes)
+	r.Get("/players", h.GetPlayers)
+	r.Get("/players/queue", h.GetPlayerQueue)
+	r.Get("/players/:id", h.GetPlayer)
+	r.Get("/players/:id/history", h.GetPlayerHistory) // Past seasons, from the archives
+	r.Post("/players", middleware.RequireRole("admin", "super_admin"), h.CreatePlayer)
+	r.Post("/players/import", middleware.RequireRole("admin", "super_admin"), h.ImportPlayers)
+	r.Put("/players/:id", middleware.RequireRole("admin", "super_admin"), h.UpdatePlayer)
+	r.Delete("/players/:id", middleware.RequireRole("super_admin"), h.DeletePlayer)
+
+	// Auction
+	r.Get("/auction/state", etag.New(), h.GetAuctionState)
+	r.Post("/auction/start", middleware.RequireRole("host", "admin", "super_admin"), h.StartAuction)
+	r.Post("/auction/end", middleware.RequireRole("host", "admin", "super_admin"), h.EndAuction)
+	r.Post("/auction/pause", middleware.RequireRole("host", "admin", "super_admin"), h.PauseAuction)
+	r.Post("/auction/resume", middleware.RequireRole("host", "admin", "super_admin"), h.ResumeAuction)
+	r.Post("/auction/next-player", middleware.RequireRole("host", "admin", "super_admin"), h.NextPlayer)
+	r.Post("/auction/start-player/:playerId", middleware.RequireRole("host", "admin", "super_admin"), h.StartBidForPlayer)
+	r.Post("/auction/sell", middleware.RequireRole("host", "admin", "super_admin"), h.SellPlayer)
+	r.Post("/auction/sell-to-team/:teamId", middleware.RequireRole("host", "admin", "super_admin"), h.SellToTeam) // Manual tie-breaking
+	r.Post("/auction/rtm", middleware.RequireRole("bidder"), h.RespondRTM) // Use or decline a right to match
+	r.Post("/auction/rtm/for-team", middleware.RequireRole("host", "admin", "super_admin"), h.RespondRTMForTeam) // Answer for the former team
+	r.Post("/auction/unsold", middleware.RequireRole("host", "admin", "super_admin"), h.MarkUnsold)
+	r.Post("/auction/unsell/:playerId", middleware.RequireRole("host", "admin", "super_admin"), h.UnsellPlayer) // Reverse a completed sale
+	r.Post("/auction/skip-player", middleware.RequireRole("host", "admin", "super_admin"), h.SkipPlayer)
+	r.Post("/auction/reset-timer", middleware.RequireRole("host", "admin", "super_admin"), h.ResetTimer)
+	r.Post("/auction/auto-close", middleware.RequireRole("host", "admin", "super_admin"), h.SetAutoClose) // Auto sell/unsold on timer expiry
+	r.Post("/auction/undo-bid", middleware.RequireRole("host", "admin", "super_admin"), h.UndoBid)
+	r.Post("/auction/archive", middleware.RequireRole("admin", "super_admin"), h.ArchiveAuction) // Snapshot results into the season archive
+	r.Post("/auction/reset", middleware.RequireRole("super_admin"), h.ResetAuction)           // Full reset
+	r.Post("/auction/reset-everything", middleware.RequireRole("super_admin"), h.ResetEverything) // Complete reset of the auction
+	r.Post("/auction/broadcast-live", middleware.RequireRole("host", "admin", "super_admin"), h.BroadcastLive) // Go live
+	r.Post("/auction/stream-url", middleware.RequireRole("host", "admin", "super_admin"), h.SetStreamUrl) // Set YouTube stream URL
+	r.Post("/auction/toggle-bidder-bidding", middleware.RequireRole("host", "admin", "super_admin"), h.ToggleBidderBidding) // Toggle bidder bidding disabled
+
+	// Bids
+	r.Post("/bids", middleware.RequireRole("bidder"), h.PlaceBid)
+	r.Post("/bids/for-team", middleware.RequireRole("host", "admin", "super_admin"), h.PlaceBidForTeam)
+	r.Get("/bids/history/:playerId", h.GetBidHistory)
+
+	// Settings
+	r.Get("/settings", middleware.RequireRole("admin", "super_admin"), h.GetSettings)
+	r.Put("/settings", middleware.RequireRole("super_admin"), h.UpdateSettings)
+
+	// Stats
+	r.Get("/stats/overview", h.GetOverviewStats)
+	r.Get("/stats/top-buys", h.GetTopBuys)
+	r.Get("/stats/recent-sales", h.GetRecentSales)
+
+	// Exports (?format=csv|xlsx)
+	r.Get("/exports/sales", middleware.RequireRole("host", "admin", "super_admin"), h.ExportSales)
+	r.Get("/exports/squads", middleware.RequireRole("host", "admin", "super_admin"), h.ExportSquads)
+	r.Get("/exports/unsold", middleware.RequireRole("host", "admin", "super_admin"), h.ExportUnsold)
+	r.Get("/exports/results", middleware.RequireRole("host", "admin", "super_admin"), h.ExportResults) // XLSX workbook of all three
+}
@@ -93,6 +93,9 @@ func RunMigrations(db *pgxpool.Pool) error {
 		updated_at TIMESTAMP DEFAULT NOW()
 	);
 
+	-- Add auto_close column if it doesn't exist (for existing databases)
+	ALTER TABLE auctions ADD COLUMN IF NOT EXISTS auto_close BOOLEAN NOT NULL DEFAULT FALSE;
+
 	-- Bids table
 	CREATE TABLE IF NOT EXISTS bids (
 		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -104,6 +107,26 @@ func RunMigrations(db *pgxpool.Pool) error {
 		is_winning BOOLEAN DEFAULT FALSE
 	);
 
+	-- Sequence number giving bids a total acceptance order (bid_time can tie)
+	ALTER TABLE bids ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+	-- Sales table (one row per completed sale, pointing at the winning bid)
+	CREATE TABLE IF NOT EXISTS sales (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		auction_id UUID REFERENCES auctions(id) ON DELETE CASCADE,
+		player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+		team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+		bid_id UUID REFERENCES bids(id) ON DELETE SET NULL,
+		amount BIGINT NOT NULL,
+		sold_at TIMESTAMP DEFAULT NOW()
+	);
+
+	-- Reversed sales are kept for audit; their bids are voided rather than deleted
+	ALTER TABLE sales ADD COLUMN IF NOT EXISTS reversed_at TIMESTAMP;
+	ALTER TABLE sales ADD COLUMN IF NOT EXISTS reversed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+	ALTER TABLE sales ADD COLUMN IF NOT EXISTS reversal_reason TEXT;
+	ALTER TABLE bids ADD COLUMN IF NOT EXISTS voided BOOLEAN NOT NULL DEFAULT FALSE;
+
 	-- Settings table
 	CREATE TABLE IF NOT EXISTS settings (
 		key VARCHAR(100) PRIMARY KEY,
@@ -111,12 +134,191 @@ func RunMigrations(db *pgxpool.Pool) error {
 		updated_at TIMESTAMP DEFAULT NOW()
 	);
 
+	-- Each auction (a tournament such as a men's, women's or U-19 league) owns its teams,
+	-- players and settings. Settings without an auction are the defaults for every auction.
+	ALTER TABLE teams ADD COLUMN IF NOT EXISTS auction_id UUID REFERENCES auctions(id) ON DELETE CASCADE;
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS auction_id UUID REFERENCES auctions(id) ON DELETE CASCADE;
+	ALTER TABLE settings ADD COLUMN IF NOT EXISTS auction_id UUID REFERENCES auctions(id) ON DELETE CASCADE;
+	ALTER TABLE settings DROP CONSTRAINT IF EXISTS settings_pkey;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_settings_auction_key
+		ON settings (COALESCE(auction_id, '00000000-0000-0000-0000-000000000000'::uuid), key);
+
+	-- Teams and players from before auctions were scoped join the latest auction
+	DO $$
+	DECLARE
+		latest UUID;
+	BEGIN
+		IF EXISTS (SELECT 1 FROM teams WHERE auction_id IS NULL)
+			OR EXISTS (SELECT 1 FROM players WHERE auction_id IS NULL) THEN
+			SELECT id INTO latest FROM auctions ORDER BY created_at DESC LIMIT 1;
+			IF latest IS NULL THEN
+				INSERT INTO auctions (name, season, status)
+				VALUES (
+					COALESCE((SELECT value #>> '{}' FROM settings WHERE key = 'tournament_name' AND auction_id IS NULL), 'Auction Session'),
+					COALESCE((SELECT value #>> '{}' FROM settings WHERE key = 'season' AND auction_id IS NULL), '2026'),
+					'pending'
+				)
+				RETURNING id INTO latest;
+			END IF;
+			UPDATE teams SET auction_id = latest WHERE auction_id IS NULL;
+			UPDATE players SET auction_id = latest WHERE auction_id IS NULL;
+		END IF;
+	END $$;
+
+	-- Organisations (independent leagues) own their users and auctions, and through the
+	-- auctions their teams, players, bids and settings. Super admins without an
+	-- organisation run the platform.
+	CREATE TABLE IF NOT EXISTS organizations (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		name VARCHAR(255) NOT NULL,
+		slug VARCHAR(100) UNIQUE NOT NULL,
+		created_at TIMESTAMP DEFAULT NOW(),
+		updated_at TIMESTAMP DEFAULT NOW()
+	);
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+	ALTER TABLE auctions ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+	ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+	ALTER TABLE users ADD CONSTRAINT users_role_check
+		CHECK (role IN ('super_admin', 'org_admin', 'admin', 'host', 'bidder', 'viewer'));
+
+	-- Users and auctions from before organisations join a default one
+	DO $$
+	DECLARE
+		org UUID;
+	BEGIN
+		IF EXISTS (SELECT 1 FROM auctions WHERE organization_id IS NULL)
+			OR EXISTS (SELECT 1 FROM users WHERE organization_id IS NULL AND role <> 'super_admin') THEN
+			INSERT INTO organizations (name, slug) VALUES ('Default Organisation', 'default')
+			ON CONFLICT (slug) DO UPDATE SET updated_at = organizations.updated_at
+			RETURNING id INTO org;
+			UPDATE auctions SET organization_id = org WHERE organization_id IS NULL;
+			UPDATE users SET organization_id = org WHERE organization_id IS NULL AND role <> 'super_admin';
+		END IF;
+	END $$;
+
+	-- Right to match: a player's former franchise may take them at the winning bid. The
+	-- auction row holds the team whose window is open; sales record whether a card was used.
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS previous_team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+	ALTER TABLE auctions ADD COLUMN IF NOT EXISTS rtm_team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+	ALTER TABLE sales ADD COLUMN IF NOT EXISTS rtm BOOLEAN NOT NULL DEFAULT FALSE;
+
+	-- Each auction's broadcast overlays are unlocked by a token of its own, so one league's
+	-- overlay URL cannot stream another's
+	ALTER TABLE auctions ADD COLUMN IF NOT EXISTS overlay_token TEXT NOT NULL
+		DEFAULT replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', '');
+
+	-- Uncapped players (no international cap yet) have their own retention price and limit
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS uncapped BOOLEAN NOT NULL DEFAULT FALSE;
+
+	-- Season archives: immutable snapshots of an auction's results (settings, purses,
+	-- squads, sales and bids) taken before it is reset. They keep no foreign keys to the
+	-- live tables, so they outlive the auction, its teams and players.
+	CREATE TABLE IF NOT EXISTS season_archives (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		organization_id UUID,
+		auction_id UUID NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		season VARCHAR(50) NOT NULL,
+		status VARCHAR(50) NOT NULL,
+		settings JSONB NOT NULL DEFAULT '{}',
+		total_spent BIGINT NOT NULL DEFAULT 0,
+		players_sold INT NOT NULL DEFAULT 0,
+		players_unsold INT NOT NULL DEFAULT 0,
+		reason VARCHAR(50) NOT NULL CHECK (reason IN ('manual', 'reset', 'reset_everything')),
+		archived_by UUID,
+		archived_at TIMESTAMP NOT NULL DEFAULT clock_timestamp()
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_teams (
+		archive_id UUID NOT NULL REFERENCES season_archives(id),
+		team_id UUID NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		short_name VARCHAR(10) NOT NULL,
+		color VARCHAR(7) NOT NULL,
+		logo_url VARCHAR(500),
+		budget BIGINT NOT NULL,
+		spent BIGINT NOT NULL,
+		player_count INT NOT NULL,
+		PRIMARY KEY (archive_id, team_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_players (
+		archive_id UUID NOT NULL REFERENCES season_archives(id),
+		player_id UUID NOT NULL,
+		identity_key TEXT NOT NULL, -- lower-cased "name|country", the same player across seasons
+		name VARCHAR(255) NOT NULL,
+		country VARCHAR(100) NOT NULL,
+		role VARCHAR(50) NOT NULL,
+		category VARCHAR(50),
+		base_price BIGINT NOT NULL,
+		status VARCHAR(50) NOT NULL,
+		sold_price BIGINT,
+		team_id UUID,
+		sold_at TIMESTAMP,
+		PRIMARY KEY (archive_id, player_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_sales (
+		archive_id UUID NOT NULL REFERENCES season_archives(id),
+		sale_id UUID NOT NULL,
+		player_id UUID NOT NULL,
+		team_id UUID NOT NULL,
+		bid_id UUID,
+		amount BIGINT NOT NULL,
+		sold_at TIMESTAMP NOT NULL,
+		reversed_at TIMESTAMP,
+		reversal_reason TEXT,
+		PRIMARY KEY (archive_id, sale_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_bids (
+		archive_id UUID NOT NULL REFERENCES season_archives(id),
+		bid_id UUID NOT NULL,
+		player_id UUID NOT NULL,
+		team_id UUID NOT NULL,
+		amount BIGINT NOT NULL,
+		bid_time TIMESTAMP NOT NULL,
+		seq BIGINT NOT NULL,
+		is_winning BOOLEAN NOT NULL,
+		voided BOOLEAN NOT NULL,
+		PRIMARY KEY (archive_id, bid_id)
+	);
+
+	ALTER TABLE archived_sales ADD COLUMN IF NOT EXISTS rtm BOOLEAN NOT NULL DEFAULT FALSE;
+
+	-- Archived rows can be added but never changed or removed
+	CREATE OR REPLACE FUNCTION archive_immutable() RETURNS trigger AS $$
+	BEGIN
+		RAISE EXCEPTION 'season archives are immutable';
+	END $$ LANGUAGE plpgsql;
+
+	DO $$
+	DECLARE
+		t TEXT;
+	BEGIN
+		FOREACH t IN ARRAY ARRAY['season_archives', 'archived_teams', 'archived_players', 'archived_sales', 'archived_bids'] LOOP
+			IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = t || '_immutable') THEN
+				EXECUTE format('CREATE TRIGGER %I BEFORE UPDATE OR DELETE OR TRUNCATE ON %I FOR EACH STATEMENT EXECUTE FUNCTION archive_immutable()', t || '_immutable', t);
+			END IF;
+		END LOOP;
+	END $$;
+
 	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_season_archives_organization_id ON season_archives(organization_id, archived_at);
+	CREATE INDEX IF NOT EXISTS idx_season_archives_auction_id ON season_archives(auction_id);
+	CREATE INDEX IF NOT EXISTS idx_archived_players_identity_key ON archived_players(identity_key);
+	CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id);
+	CREATE INDEX IF NOT EXISTS idx_auctions_organization_id ON auctions(organization_id);
+	CREATE INDEX IF NOT EXISTS idx_teams_auction_id ON teams(auction_id);
+	CREATE INDEX IF NOT EXISTS idx_players_auction_id ON players(auction_id);
 	CREATE INDEX IF NOT EXISTS idx_players_status ON players(status);
 	CREATE INDEX IF NOT EXISTS idx_players_team_id ON players(team_id);
 	CREATE INDEX IF NOT EXISTS idx_players_queue_order ON players(queue_order);
 	CREATE INDEX IF NOT EXISTS idx_bids_player_id ON bids(player_id);
 	CREATE INDEX IF NOT EXISTS idx_bids_auction_id ON bids(auction_id);
+	CREATE INDEX IF NOT EXISTS idx_bids_player_seq ON bids(player_id, seq);
+	CREATE INDEX IF NOT EXISTS idx_sales_player_id ON sales(player_id);
+	CREATE INDEX IF NOT EXISTS idx_sales_auction_id ON sales(auction_id);
 	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 	CREATE INDEX IF NOT EXISTS idx_users_team_id ON users(team_id);
 
@@ -129,8 +331,13 @@ func RunMigrations(db *pgxpool.Pool) error {
 		('min_squad_size', '18'),
 		('max_squad_size', '25'),
 		('timer_duration', '30'),
+		('home_country', '"India"'),
+		('auto_close_on_timer', 'false'),
+		('rtm_cards', '0'),
+		('rtm_window', '20'),
+		('retention_rules', '{"max_retentions": 0, "max_overseas": 0, "max_uncapped": 0, "slabs": [], "uncapped_price": 0}'),
 		('bid_increments', '[2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 12000, 14000, 16000, 18000, 20000, 24000, 28000, 32000, 36000, 40000, 45000, 50000]')
-	ON CONFLICT (key) DO NOTHING;
+	ON CONFLICT (COALESCE(auction_id, '00000000-0000-0000-0000-000000000000'::uuid), key) DO NOTHING;
 
 	-- Insert default super admin (password: admin123)
 	INSERT INTO users (email, password_hash, name, role, status) VALUES
@@ -7,6 +7,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// MaxConns is the size of the connection pool
+const MaxConns = 25
+
 // Connect establishes a connection pool to PostgreSQL
 func Connect(databaseURL string) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
@@ -15,7 +18,7 @@ func Connect(databaseURL string) (*pgxpool.Pool, error) {
 	}
 
 	// Connection pool settings
-	config.MaxConns = 25
+	config.MaxConns = MaxConns
 	config.MinConns = 5
 	config.MaxConnLifetime = time.Hour
 	config.MaxConnIdleTime = 30 * time.Minute
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ArchiveAuction snapshots the auction's results into the season archive
+func (h *Handlers) ArchiveAuction(c *fiber.Ctx) error {
+	if _, ok := c.Locals("auction").(*models.Auction); !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No auction selected",
+		})
+	}
+	h = h.scoped(c)
+
+	var archivedBy *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		archivedBy = &userID
+	}
+
+	archive, err := h.services.Archives.Archive(c.Context(), archivedBy)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(archive)
+}
+
+// GetArchives lists the organisation's past seasons, newest first (?auction= limits
+// them to one auction)
+func (h *Handlers) GetArchives(c *fiber.Ctx) error {
+	var auctionID *uuid.UUID
+	if raw := c.Query("auction"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid auction ID",
+			})
+		}
+		auctionID = &id
+	}
+
+	archives, err := h.tenant(c).Archives.List(c.Context(), auctionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(archives)
+}
+
+// GetArchive returns a past season with its purses, squads, unsold players and sales
+func (h *Handlers) GetArchive(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid archive ID",
+		})
+	}
+
+	archive, err := h.tenant(c).Archives.Get(c.Context(), id)
+	if err != nil {
+		return archiveError(c, err)
+	}
+	return c.JSON(archive)
+}
+
+// GetArchiveBids returns a past season's bids in order (?player= limits them to one player)
+func (h *Handlers) GetArchiveBids(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid archive ID",
+		})
+	}
+	var playerID *uuid.UUID
+	if raw := c.Query("player"); raw != "" {
+		pid, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid player ID",
+			})
+		}
+		playerID = &pid
+	}
+
+	bids, err := h.tenant(c).Archives.GetBids(c.Context(), id, playerID)
+	if err != nil {
+		return archiveError(c, err)
+	}
+	return c.JSON(bids)
+}
+
+// GetArchivedPlayerHistory returns a player's past seasons by ?name= and ?country=
+func (h *Handlers) GetArchivedPlayerHistory(c *fiber.Ctx) error {
+	history, err := h.tenant(c).Archives.PlayerHistory(c.Context(), c.Query("name"), c.Query("country"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(history)
+}
+
+// GetPlayerHistory returns the past seasons of one of the auction's players
+func (h *Handlers) GetPlayerHistory(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	player, err := h.services.Players.GetByID(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Player not found",
+		})
+	}
+	history, err := h.services.Archives.PlayerHistory(c.Context(), player.Name, player.Country)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(history)
+}
+
+// archiveError answers 404 for an unknown archive and 500 otherwise
+func archiveError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrArchiveNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Archive not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -1,69 +1,100 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/auctionapp/backend/internal/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-// GetAuctionState returns the current auction state
+// GetAuctionState returns the current auction state from the materialised snapshot.
+// The route's ETag middleware answers a matching If-None-Match with 304.
 func (h *Handlers) GetAuctionState(c *fiber.Ctx) error {
-	state, err := h.services.Auction.GetState(c.Context())
+	h = h.scoped(c)
+	state, err := h.services.Auction.CachedState(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	// Hosts and admins also see who is connected
+	if role, ok := c.Locals("role").(string); ok {
+		state = h.hub.HostView(c.Context(), state, role)
+	}
+	// Clients may keep the body but must revalidate it every time
+	c.Set(fiber.HeaderCacheControl, "no-cache")
 	return c.JSON(state)
 }
 
 // StartAuction starts or resumes an auction
 func (h *Handlers) StartAuction(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	auction, err := h.services.Auction.StartAuction(c.Context())
 	if err != nil {
+		if errors.Is(err, services.ErrAuctionCompleted) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	// Broadcast to all clients
-	h.hub.Broadcast <- []byte(`{"event":"auction:started"}`)
+	h.hub.BroadcastRaw([]byte(`{"event":"auction:started"}`))
 
 	return c.JSON(auction)
 }
 
 // PauseAuction pauses the auction
 func (h *Handlers) PauseAuction(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	if err := h.services.Auction.PauseAuction(c.Context()); err != nil {
+		if errors.Is(err, services.ErrAuctionCompleted) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	// Broadcast pause event
-	h.hub.Broadcast <- []byte(`{"event":"auction:paused"}`)
+	h.hub.BroadcastRaw([]byte(`{"event":"auction:paused"}`))
 
-	// Broadcast full state for immediate synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast the status change for immediate synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
 
 	return c.JSON(fiber.Map{"message": "Auction paused"})
 }
 
 // ResumeAuction resumes a paused auction
 func (h *Handlers) ResumeAuction(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	if err := h.services.Auction.ResumeAuction(c.Context()); err != nil {
+		if errors.Is(err, services.ErrAuctionCompleted) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	// Broadcast resume event
-	h.hub.Broadcast <- []byte(`{"event":"auction:resumed"}`)
+	h.hub.BroadcastRaw([]byte(`{"event":"auction:resumed"}`))
 
-	// Broadcast full state for immediate synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast the status change for immediate synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
 
 	return c.JSON(fiber.Map{"message": "Auction resumed"})
 }
@@ -72,28 +103,27 @@ func (h *Handlers) ResumeAuction(c *fiber.Ctx) error {
 
 // EndAuction ends the auction
 func (h *Handlers) EndAuction(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	if err := h.services.Auction.EndAuction(c.Context()); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	// Get updated state first (with completed status)
-	state, _ := h.services.Auction.GetState(c.Context())
-
 	// Broadcast end event with proper format
 	h.hub.BroadcastJSON("auction:ended", fiber.Map{
 		"status": "completed",
 	})
 
-	// Broadcast full state for immediate synchronization (this ensures status is "completed")
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast the status change for immediate synchronization (this ensures status is "completed")
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
 
 	return c.JSON(fiber.Map{"message": "Auction ended"})
 }
 
 // NextPlayer moves to the next player
 func (h *Handlers) NextPlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	player, err := h.services.Auction.NextPlayer(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -104,15 +134,15 @@ func (h *Handlers) NextPlayer(c *fiber.Ctx) error {
 	// Broadcast new player to all clients
 	h.hub.BroadcastJSON("auction:player-changed", player)
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotOpened|websocket.ChangeQueue)
 
 	return c.JSON(player)
 }
 
 // StartBidForPlayer starts bidding on a specific player selected by host
 func (h *Handlers) StartBidForPlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	playerID, err := uuid.Parse(c.Params("playerId"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -130,42 +160,47 @@ func (h *Handlers) StartBidForPlayer(c *fiber.Ctx) error {
 	// Broadcast new player to all clients
 	h.hub.BroadcastJSON("auction:player-changed", player)
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotOpened|websocket.ChangeQueue)
 
 	return c.JSON(player)
 }
 
 // SellPlayer marks the current player as sold
 func (h *Handlers) SellPlayer(c *fiber.Ctx) error {
-	player, team, err := h.services.Auction.SellPlayer(c.Context())
+	h = h.scoped(c)
+	sale, err := h.services.Auction.SellPlayer(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	// The sale waits for the player's former team to use or decline its right to match
+	if sale.RTM != nil {
+		h.hub.BroadcastRTMOffer(c.Context(), h.services, sale.RTM)
+		return c.JSON(fiber.Map{
+			"message": "Right to match offered",
+			"rtm":     sale.RTM,
+		})
+	}
+
 	// Broadcast sale to all clients
-	h.hub.BroadcastJSON("auction:sold", fiber.Map{
-		"player": player,
-		"team":   team,
-		"amount": player.SoldPrice,
-	})
+	h.hub.BroadcastSold(h.services, sale.Player, sale.Team)
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotClosed|websocket.ChangeTeams)
 
 	return c.JSON(fiber.Map{
 		"message": "Player sold",
-		"player":  player,
-		"team":    team,
+		"player":  sale.Player,
+		"team":    sale.Team,
 	})
 }
 
 // SellToTeam manually allocates the current player to a specific team (for tie-breaking at max bid)
 func (h *Handlers) SellToTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	teamID, err := uuid.Parse(c.Params("teamId"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -173,33 +208,77 @@ func (h *Handlers) SellToTeam(c *fiber.Ctx) error {
 		})
 	}
 
-	player, team, err := h.services.Auction.SellToTeam(c.Context(), teamID)
+	sale, err := h.services.Auction.SellToTeam(c.Context(), teamID)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	// The sale waits for the player's former team to use or decline its right to match
+	if sale.RTM != nil {
+		h.hub.BroadcastRTMOffer(c.Context(), h.services, sale.RTM)
+		return c.JSON(fiber.Map{
+			"message": "Right to match offered",
+			"rtm":     sale.RTM,
+		})
+	}
+
 	// Broadcast sale to all clients
-	h.hub.BroadcastJSON("auction:sold", fiber.Map{
-		"player": player,
-		"team":   team,
-		"amount": player.SoldPrice,
-	})
+	h.hub.BroadcastSold(h.services, sale.Player, sale.Team)
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotClosed|websocket.ChangeTeams)
 
 	return c.JSON(fiber.Map{
 		"message": "Player sold (manual allocation)",
-		"player":  player,
-		"team":    team,
+		"player":  sale.Player,
+		"team":    sale.Team,
 	})
 }
 
+// RespondRTM uses or declines the bidder's team's right to match on the player just hammered
+func (h *Handlers) RespondRTM(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	teamID, ok := c.Locals("teamID").(*uuid.UUID)
+	if !ok || teamID == nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "No team assigned to this bidder",
+		})
+	}
+	return h.respondRTM(c, teamID)
+}
+
+// RespondRTMForTeam lets the host answer the open right to match for the former team
+func (h *Handlers) RespondRTMForTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	return h.respondRTM(c, nil)
+}
+
+// respondRTM closes the right-to-match window and broadcasts the sale it made
+func (h *Handlers) respondRTM(c *fiber.Ctx, teamID *uuid.UUID) error {
+	var req models.RTMResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.services.Auction.RespondRTM(c.Context(), teamID, req.Accept)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	h.hub.BroadcastRTMResult(c.Context(), h.services, result)
+
+	return c.JSON(result)
+}
+
 // MarkUnsold marks the current player as unsold
 func (h *Handlers) MarkUnsold(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	player, err := h.services.Auction.MarkUnsold(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -209,15 +288,59 @@ func (h *Handlers) MarkUnsold(c *fiber.Ctx) error {
 
 	h.hub.BroadcastJSON("auction:unsold", fiber.Map{"player": player})
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotClosed|websocket.ChangeQueue)
 
 	return c.JSON(fiber.Map{"message": "Player marked unsold", "player": player})
 }
 
+// UnsellPlayer reverses a completed sale (e.g. the host sold to the wrong team)
+func (h *Handlers) UnsellPlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	playerID, err := uuid.Parse(c.Params("playerId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	var req models.UnsellRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	var reversedBy *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		reversedBy = &userID
+	}
+
+	result, err := h.services.Auction.UnsellPlayer(c.Context(), playerID, req.Mode, reversedBy, req.Reason)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Tell clients to correct any sold cards / purse figures they are showing
+	h.hub.BroadcastJSON("auction:sale-reversed", result)
+
+	// Broadcast what changed for synchronization
+	changes := websocket.ChangeTeams | websocket.ChangeQueue
+	if result.Mode == "block" {
+		changes |= websocket.ChangeLotOpened
+	}
+	h.hub.BroadcastChanges(c.Context(), h.services, changes)
+
+	return c.JSON(fiber.Map{"message": "Sale reversed", "result": result})
+}
+
 // SkipPlayer skips the current player and returns them to the queue
 func (h *Handlers) SkipPlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	player, err := h.services.Auction.SkipPlayer(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -227,9 +350,8 @@ func (h *Handlers) SkipPlayer(c *fiber.Ctx) error {
 
 	h.hub.BroadcastJSON("auction:skipped", fiber.Map{"player": player})
 
-	// Broadcast full state for synchronization
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast what changed for synchronization
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotClosed|websocket.ChangeQueue)
 
 	return c.JSON(fiber.Map{"message": "Player skipped and returned to queue", "player": player})
 }
@@ -237,6 +359,7 @@ func (h *Handlers) SkipPlayer(c *fiber.Ctx) error {
 
 // ResetTimer resets the bid timer
 func (h *Handlers) ResetTimer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	remaining, err := h.services.Auction.ResetTimer(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -249,23 +372,97 @@ func (h *Handlers) ResetTimer(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"remaining": remaining})
 }
 
+// SetAutoClose chooses between closing lots automatically on timer expiry and waiting for the host's hammer
+func (h *Handlers) SetAutoClose(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	var req struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.services.Auction.SetAutoClose(c.Context(), req.Enabled); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Broadcast the updated setting to all clients
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
+
+	return c.JSON(fiber.Map{
+		"enabled": req.Enabled,
+		"message": "Auto-close updated",
+	})
+}
+
+// onTimerTick broadcasts the remaining seconds of the lot countdown
+func (h *Handlers) onTimerTick(remaining int) {
+	h.hub.BroadcastJSON("auction:timer", fiber.Map{"remaining": remaining})
+}
+
+// onTimerExpired closes the lot when auto-close is on, or tells the host the hammer is due.
+// An unanswered right to match lapses.
+func (h *Handlers) onTimerExpired() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := h.services.Auction.CloseExpiredLot(ctx)
+	if err != nil {
+		log.Printf("Timer expiry: could not close lot: %v", err)
+		return
+	}
+
+	h.hub.BroadcastJSON("auction:timer", fiber.Map{"remaining": 0, "expired": true})
+
+	if result.RTM != nil {
+		h.hub.BroadcastRTMOffer(ctx, h.services, result.RTM)
+		return
+	}
+	if result.Lapsed != nil {
+		h.hub.BroadcastRTMResult(ctx, h.services, result.Lapsed)
+		return
+	}
+	if !result.Closed {
+		// Waiting for the host's hammer
+		return
+	}
+
+	if result.Sold {
+		h.hub.BroadcastSold(h.services, result.Player, result.Team)
+	} else {
+		h.hub.BroadcastJSON("auction:unsold", fiber.Map{"player": result.Player})
+	}
+
+	// Broadcast what changed for synchronization
+	changes := websocket.ChangeLotClosed | websocket.ChangeQueue
+	if result.Sold {
+		changes = websocket.ChangeLotClosed | websocket.ChangeTeams
+	}
+	h.hub.BroadcastChanges(ctx, h.services, changes)
+}
+
 // UndoBid removes the last bid
 func (h *Handlers) UndoBid(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	if err := h.services.Auction.UndoBid(c.Context()); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	// Get updated state and broadcast
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast the lot's corrected bidding
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeLotUpdated)
 
 	return c.JSON(fiber.Map{"message": "Last bid undone"})
 }
 
 // PlaceBid places a bid
 func (h *Handlers) PlaceBid(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	teamID, ok := c.Locals("teamID").(*uuid.UUID)
 	if !ok || teamID == nil {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -288,13 +485,14 @@ func (h *Handlers) PlaceBid(c *fiber.Ctx) error {
 	}
 
 	// Broadcast bid to all clients
-	h.hub.BroadcastJSON("auction:bid", bid)
+	h.hub.BroadcastBid(c.Context(), h.services, bid)
 
 	return c.JSON(bid)
 }
 
 // PlaceBidForTeam allows host/admin to place a bid on behalf of a team
 func (h *Handlers) PlaceBidForTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var req models.PlaceBidForTeamRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -318,13 +516,14 @@ func (h *Handlers) PlaceBidForTeam(c *fiber.Ctx) error {
 	}
 
 	// Broadcast bid to all clients
-	h.hub.BroadcastJSON("auction:bid", bid)
+	h.hub.BroadcastBid(c.Context(), h.services, bid)
 
 	return c.JSON(bid)
 }
 
 // ToggleBidderBidding toggles the bidder bidding disabled state
 func (h *Handlers) ToggleBidderBidding(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var req struct {
 		Disabled bool `json:"disabled"`
 	}
@@ -337,9 +536,8 @@ func (h *Handlers) ToggleBidderBidding(c *fiber.Ctx) error {
 	// Set the disabled state in Redis
 	h.services.Auction.SetBidderBiddingDisabled(c.Context(), req.Disabled)
 
-	// Broadcast updated state to all clients
-	state, _ := h.services.Auction.GetState(c.Context())
-	h.hub.BroadcastJSON("auction:state", state)
+	// Broadcast the updated switch to all clients
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
 
 	return c.JSON(fiber.Map{
 		"disabled": req.Disabled,
@@ -349,6 +547,7 @@ func (h *Handlers) ToggleBidderBidding(c *fiber.Ctx) error {
 
 // GetBidHistory returns bid history for a player
 func (h *Handlers) GetBidHistory(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	playerID, err := uuid.Parse(c.Params("playerId"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -367,6 +566,13 @@ func (h *Handlers) GetBidHistory(c *fiber.Ctx) error {
 
 // ResetAuction resets the entire auction - clears all bids, player statuses, team spent amounts
 func (h *Handlers) ResetAuction(c *fiber.Ctx) error {
+	// A reset is limited to the auction the request resolved to, never every tenant's data
+	if _, ok := c.Locals("auction").(*models.Auction); !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No auction selected",
+		})
+	}
+	h = h.scoped(c)
 	if err := h.services.Auction.ResetAuction(c.Context()); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -381,30 +587,40 @@ func (h *Handlers) ResetAuction(c *fiber.Ctx) error {
 
 // BroadcastLive broadcasts to all clients that the auction is now live
 func (h *Handlers) BroadcastLive(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	// Update auction status to live
 	if err := h.services.Auction.SetLiveStatus(c.Context()); err != nil {
+		if errors.Is(err, services.ErrAuctionCompleted) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	// Get current state
-	state, _ := h.services.Auction.GetState(c.Context())
-
 	// Broadcast live announcement to all clients
 	h.hub.BroadcastJSON("auction:live", fiber.Map{
 		"message": "Auction is now live! Owners are preparing. Waiting for the first player to appear.",
 		"status":  "live",
 	})
 
-	// Also broadcast full state
-	h.hub.BroadcastJSON("auction:state", state)
+	// Also broadcast the status change
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeStatus)
 
 	return c.JSON(fiber.Map{"message": "Broadcast sent - auction is now live"})
 }
 
-// ResetEverything completely resets the entire system - deletes all teams, players, bids, and auctions
+// ResetEverything completely resets the auction - deletes its teams, players, bids and sales
 func (h *Handlers) ResetEverything(c *fiber.Ctx) error {
+	// A reset is limited to the auction the request resolved to, never every tenant's data
+	if _, ok := c.Locals("auction").(*models.Auction); !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No auction selected",
+		})
+	}
+	h = h.scoped(c)
 	if err := h.services.Auction.ResetEverything(c.Context()); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/auctionapp/backend/internal/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetAuctions returns every auction of the organisation, newest first
+func (h *Handlers) GetAuctions(c *fiber.Ctx) error {
+	auctions, err := h.tenant(c).Auctions.List(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(auctions)
+}
+
+// GetAuction returns an auction by ID
+func (h *Handlers) GetAuction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("auctionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid auction ID",
+		})
+	}
+
+	auction, err := h.tenant(c).Auctions.Get(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Auction not found",
+		})
+	}
+	return c.JSON(auction)
+}
+
+// CreateAuction creates a pending auction with no teams or players
+func (h *Handlers) CreateAuction(c *fiber.Ctx) error {
+	var req models.CreateAuctionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	auction, err := h.tenant(c).Auctions.Create(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrganizationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Organization not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(auction)
+}
+
+// UpdateAuction changes an auction's name, season, timer duration or auto-close mode
+func (h *Handlers) UpdateAuction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("auctionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid auction ID",
+		})
+	}
+
+	var req models.UpdateAuctionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	auction, err := h.tenant(c).Auctions.Update(c.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, services.ErrAuctionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Auction not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Tell the auction's clients about the new details
+	h.hub.ForAuction(id).BroadcastChanges(c.Context(), h.services.ForAuction(id), websocket.ChangeStatus)
+
+	return c.JSON(auction)
+}
+
+// DeleteAuction deletes an auction with its teams, players, bids, sales and settings
+func (h *Handlers) DeleteAuction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("auctionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid auction ID",
+		})
+	}
+
+	if err := h.tenant(c).Auctions.Delete(c.Context(), id); err != nil {
+		if errors.Is(err, services.ErrAuctionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Auction not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"message": "Auction deleted"})
+}
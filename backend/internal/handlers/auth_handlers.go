@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
 	"github.com/auctionapp/backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -204,6 +205,29 @@ func (h *Handlers) GetCurrentUser(c *fiber.Ctx) error {
 	return c.JSON(user)
 }
 
+// IssueWSTicket returns a single-use ticket for opening /ws?ticket=, so the access token
+// never appears in a WebSocket URL. The ticket only works from the requesting origin.
+func (h *Handlers) IssueWSTicket(c *fiber.Ctx) error {
+	token, ok := c.Locals("token").(string)
+	if !ok || token == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing authorization",
+		})
+	}
+
+	ticket, err := h.services.Auth.IssueWSTicket(c.Context(), token, c.Get("Origin"))
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"ticket":     ticket,
+		"expires_in": int(services.WSTicketTTL.Seconds()),
+	})
+}
+
 // ChangePassword handles password change for authenticated users
 func (h *Handlers) ChangePassword(c *fiber.Ctx) error {
 	userID, ok := c.Locals("userID").(uuid.UUID)
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetConnections lists the live WebSocket and event-stream connections on every node
+// to the organisation's auctions
+func (h *Handlers) GetConnections(c *fiber.Ctx) error {
+	connections, err := h.tenantConnections(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"connections": connections,
+		"total":       len(connections),
+	})
+}
+
+// DisconnectConnection closes one connection (?reason= is sent in the close frame)
+func (h *Handlers) DisconnectConnection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid connection ID",
+		})
+	}
+
+	// Only the organisation's own connections can be closed
+	connections, err := h.tenantConnections(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	found := false
+	for _, conn := range connections {
+		found = found || conn.ID == id
+	}
+	if !found || !h.hub.DisconnectClient(c.Context(), id, c.Query("reason")) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Connection not found",
+		})
+	}
+	return c.JSON(fiber.Map{"message": "Connection closed"})
+}
+
+// DisconnectUserConnections closes every connection of a user (?reason= is sent in the close frame)
+func (h *Handlers) DisconnectUserConnections(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	if _, err := h.tenant(c).Users.GetByID(c.Context(), id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	closed := h.hub.DisconnectUser(c.Context(), id, c.Query("reason"))
+	return c.JSON(fiber.Map{"message": "Connections closed", "closed": closed})
+}
+
+// tenantConnections returns the connections to the signed-in user's organisation's
+// auctions, or every connection for platform super admins
+func (h *Handlers) tenantConnections(c *fiber.Ctx) ([]models.ConnectionInfo, error) {
+	connections := h.hub.Connections(c.Context())
+	if orgID, _ := c.Locals("orgID").(*uuid.UUID); orgID == nil {
+		return connections, nil
+	}
+
+	auctions, err := h.tenant(c).Auctions.List(c.Context())
+	if err != nil {
+		return nil, err
+	}
+	own := make(map[uuid.UUID]bool, len(auctions))
+	for _, auction := range auctions {
+		own[auction.ID] = true
+	}
+	filtered := []models.ConnectionInfo{}
+	for _, conn := range connections {
+		if own[conn.AuctionID] {
+			filtered = append(filtered, conn)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/auctionapp/backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ExportSales exports the sale sheet (?format=csv|xlsx)
+func (h *Handlers) ExportSales(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	sheet, err := h.services.Exports.SaleSheet(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return sendExport(c, "sales", exportFormat(c), sheet, []utils.Sheet{sheet})
+}
+
+// ExportSquads exports team squads with remaining purse (?format=csv|xlsx&team_id=)
+// XLSX has a summary sheet plus one sheet per team; CSV has one row per player
+func (h *Handlers) ExportSquads(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	var teamID *uuid.UUID
+	if raw := c.Query("team_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid team ID",
+			})
+		}
+		teamID = &id
+	}
+
+	if exportFormat(c) == "csv" {
+		sheet, err := h.services.Exports.SquadCSVSheet(c.Context(), teamID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return sendExport(c, "squads", "csv", sheet, nil)
+	}
+
+	sheets, err := h.services.Exports.SquadSheets(c.Context(), teamID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return sendExport(c, "squads", "xlsx", utils.Sheet{}, sheets)
+}
+
+// ExportUnsold exports the unsold player list (?format=csv|xlsx)
+func (h *Handlers) ExportUnsold(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	sheet, err := h.services.Exports.UnsoldSheet(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return sendExport(c, "unsold", exportFormat(c), sheet, []utils.Sheet{sheet})
+}
+
+// ExportResults exports sales, squads and unsold players as one XLSX workbook
+func (h *Handlers) ExportResults(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	sheets, err := h.services.Exports.ResultsWorkbook(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return sendExport(c, "auction-results", "xlsx", utils.Sheet{}, sheets)
+}
+
+// exportFormat returns the requested export format, defaulting to csv
+func exportFormat(c *fiber.Ctx) string {
+	if c.Query("format") == "xlsx" {
+		return "xlsx"
+	}
+	return "csv"
+}
+
+// sendExport writes csvSheet or xlsxSheets as a dated file download in the given format
+func sendExport(c *fiber.Ctx, name, format string, csvSheet utils.Sheet, xlsxSheets []utils.Sheet) error {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("2006-01-02"), format)
+
+	var buf bytes.Buffer
+	var err error
+	if format == "xlsx" {
+		c.Set(fiber.HeaderContentType, xlsxContentType)
+		err = utils.WriteXLSX(&buf, xlsxSheets)
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		err = utils.WriteCSV(&buf, csvSheet)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to build export",
+		})
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Send(buf.Bytes())
+}
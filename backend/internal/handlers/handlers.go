@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"github.com/auctionapp/backend/internal/config"
+	"github.com/auctionapp/backend/internal/models"
 	"github.com/auctionapp/backend/internal/services"
 	"github.com/auctionapp/backend/internal/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // Handlers holds all HTTP handlers
@@ -16,11 +18,59 @@ type Handlers struct {
 
 // NewHandlers creates a new handlers instance
 func NewHandlers(svc *services.Services, hub *websocket.Hub, cfg *config.Config) *Handlers {
-	return &Handlers{
+	h := &Handlers{
 		services: svc,
 		hub:      hub,
 		cfg:      cfg,
 	}
+
+	// Each auction's service owns its lot countdown; handlers broadcast its ticks and expiry
+	svc.SetTimerCallbacks(func(auctionID uuid.UUID, scoped *services.Services) (func(int), func()) {
+		sh := &Handlers{services: scoped, hub: hub.ForAuction(auctionID), cfg: cfg}
+		return sh.onTimerTick, sh.onTimerExpired
+	})
+
+	return h
+}
+
+// AuctionScope resolves the auction a request is for: the :auctionId route parameter,
+// else ?auction=, else the bidder's team's auction, else the default auction. Signed-in
+// users only find their organisation's auctions.
+func (h *Handlers) AuctionScope(c *fiber.Ctx) error {
+	id := c.Params("auctionId")
+	if id == "" {
+		id = c.Query("auction")
+	}
+	teamID, _ := c.Locals("teamID").(*uuid.UUID)
+
+	auction, err := h.tenant(c).Auctions.Resolve(c.Context(), id, teamID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Auction not found",
+		})
+	}
+	c.Locals("auction", auction)
+	return c.Next()
+}
+
+// tenant returns the services of the signed-in user's organisation: all of them for
+// platform super admins and public requests
+func (h *Handlers) tenant(c *fiber.Ctx) *services.Services {
+	orgID, _ := c.Locals("orgID").(*uuid.UUID)
+	return h.services.ForOrganization(orgID)
+}
+
+// scoped returns the handlers of the auction AuctionScope resolved for the request
+func (h *Handlers) scoped(c *fiber.Ctx) *Handlers {
+	auction, ok := c.Locals("auction").(*models.Auction)
+	if !ok {
+		return h
+	}
+	return &Handlers{
+		services: h.services.ForAuction(auction.ID),
+		hub:      h.hub.ForAuction(auction.ID),
+		cfg:      h.cfg,
+	}
 }
 
 // ErrorHandler is a custom error handler for Fiber
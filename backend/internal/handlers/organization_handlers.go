@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetOrganizations returns every organisation
+func (h *Handlers) GetOrganizations(c *fiber.Ctx) error {
+	orgs, err := h.services.Organizations.List(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(orgs)
+}
+
+// GetOrganization returns an organisation by ID
+func (h *Handlers) GetOrganization(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID",
+		})
+	}
+
+	org, err := h.services.Organizations.Get(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Organization not found",
+		})
+	}
+	return c.JSON(org)
+}
+
+// GetCurrentOrganization returns the signed-in user's organisation
+func (h *Handlers) GetCurrentOrganization(c *fiber.Ctx) error {
+	orgID, _ := c.Locals("orgID").(*uuid.UUID)
+	if orgID == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Not part of an organization",
+		})
+	}
+
+	org, err := h.services.Organizations.Get(c.Context(), *orgID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Organization not found",
+		})
+	}
+	return c.JSON(org)
+}
+
+// CreateOrganization creates an organisation; its first users are then created with
+// its organization_id, starting with an org_admin
+func (h *Handlers) CreateOrganization(c *fiber.Ctx) error {
+	var req models.CreateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	org, err := h.services.Organizations.Create(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(org)
+}
+
+// UpdateOrganization changes an organisation's name or slug
+func (h *Handlers) UpdateOrganization(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID",
+		})
+	}
+
+	var req models.UpdateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	org, err := h.services.Organizations.Update(c.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrganizationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Organization not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(org)
+}
+
+// DeleteOrganization deletes an organisation with all of its users and auctions
+func (h *Handlers) DeleteOrganization(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID",
+		})
+	}
+
+	// The users are deleted with the organisation, so find them first to close their connections
+	users, err := h.services.ForOrganization(&id).Users.GetAll(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.services.Organizations.Delete(c.Context(), id); err != nil {
+		if errors.Is(err, services.ErrOrganizationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Organization not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	for _, user := range users {
+		h.hub.DisconnectUser(c.Context(), user.ID, "Organization deleted")
+	}
+	return c.JSON(fiber.Map{"message": "Organization deleted"})
+}
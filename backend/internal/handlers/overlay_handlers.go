@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"html/template"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/auctionapp/backend/internal/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+//go:embed templates/overlay.html
+var overlayFS embed.FS
+
+var overlayTemplate = template.Must(template.ParseFS(overlayFS, "templates/overlay.html"))
+
+// overlayGraphics are the graphics a browser source can load on its own
+var overlayGraphics = map[string]bool{
+	"all":     true,
+	"lot":     true,
+	"ticker":  true,
+	"sold":    true,
+	"purses":  true,
+	"next-up": true,
+}
+
+// GetOverlayState returns every broadcast graphic as JSON
+func (h *Handlers) GetOverlayState(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	frame, err := h.services.Overlay.Frame(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(frame)
+}
+
+// StreamOverlayEvents streams overlay:* events to a browser source
+func (h *Handlers) StreamOverlayEvents(c *fiber.Ctx) error {
+	h = h.scoped(c)
+	return websocket.HandleOverlaySSE(c, h.hub, h.services)
+}
+
+// RenderOverlay serves an HTML page for an OBS/vMix browser source showing one graphic
+// (lot, ticker, sold, purses, next-up) or all of them. The page has a transparent
+// background and keeps itself up to date from the overlay event stream.
+func (h *Handlers) RenderOverlay(c *fiber.Ctx) error {
+	graphic := c.Params("graphic")
+	if !overlayGraphics[graphic] {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Unknown overlay graphic",
+		})
+	}
+
+	var page bytes.Buffer
+	err := overlayTemplate.Execute(&page, fiber.Map{
+		"Graphic": graphic,
+		"Token":   c.Query("token"),
+		"Auction": c.Query("auction"),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(page.Bytes())
+}
+
+// OverlayTokenOf returns the overlay token of the auction AuctionScope resolved, or ""
+func (h *Handlers) OverlayTokenOf(c *fiber.Ctx) string {
+	auction, ok := c.Locals("auction").(*models.Auction)
+	if !ok {
+		return ""
+	}
+	token, err := h.tenant(c).Auctions.OverlayToken(c.Context(), auction.ID)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// GetOverlayToken returns the token an auction's overlay URLs must carry
+func (h *Handlers) GetOverlayToken(c *fiber.Ctx) error {
+	return h.overlayToken(c, (*services.AuctionsService).OverlayToken)
+}
+
+// RotateOverlayToken gives an auction a new overlay token; URLs with the old one stop working
+func (h *Handlers) RotateOverlayToken(c *fiber.Ctx) error {
+	return h.overlayToken(c, (*services.AuctionsService).RotateOverlayToken)
+}
+
+func (h *Handlers) overlayToken(c *fiber.Ctx, get func(*services.AuctionsService, context.Context, uuid.UUID) (string, error)) error {
+	id, err := uuid.Parse(c.Params("auctionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid auction ID",
+		})
+	}
+
+	token, err := get(h.tenant(c).Auctions, c.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrAuctionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Auction not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"token": token})
+}
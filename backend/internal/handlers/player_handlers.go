@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/auctionapp/backend/internal/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 // GetPlayers returns players with filters
 func (h *Handlers) GetPlayers(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	filter := models.PlayerFilter{
 		Status:   c.Query("status"),
 		Role:     c.Query("role"),
@@ -31,6 +36,7 @@ func (h *Handlers) GetPlayers(c *fiber.Ctx) error {
 
 // GetPlayer returns a player by ID
 func (h *Handlers) GetPlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -49,6 +55,7 @@ func (h *Handlers) GetPlayer(c *fiber.Ctx) error {
 
 // GetPlayerQueue returns the auction queue
 func (h *Handlers) GetPlayerQueue(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	// Handle limit parameter manually to distinguish between:
 	// - limit=0 (meaning unlimited/all players)
 	// - no limit param (use default of 10)
@@ -69,6 +76,7 @@ func (h *Handlers) GetPlayerQueue(c *fiber.Ctx) error {
 
 // CreatePlayer creates a new player
 func (h *Handlers) CreatePlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var req models.CreatePlayerRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -82,11 +90,13 @@ func (h *Handlers) CreatePlayer(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.Status(fiber.StatusCreated).JSON(player)
 }
 
 // UpdatePlayer updates a player
 func (h *Handlers) UpdatePlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -107,11 +117,13 @@ func (h *Handlers) UpdatePlayer(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.JSON(player)
 }
 
 // DeletePlayer deletes a player
 func (h *Handlers) DeletePlayer(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -124,13 +136,79 @@ func (h *Handlers) DeletePlayer(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.JSON(fiber.Map{"message": "Player deleted"})
 }
 
-// ImportPlayers handles bulk player import
+// ImportPlayers handles bulk player import from a CSV or XLSX upload
+// Form fields:
+//   - file: the spreadsheet (required)
+//   - dry_run: "true" to validate without saving
+//   - mapping: JSON object of player field -> column header, e.g. {"base_price": "Reserve (INR)"}
+//   - stats_columns: JSON array or comma-separated list of headers to store in stats
 func (h *Handlers) ImportPlayers(c *fiber.Ctx) error {
-	// TODO: Implement CSV import
-	return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
-		"error": "CSV import not yet implemented",
-	})
+	h = h.scoped(c)
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No file provided",
+		})
+	}
+
+	if file.Size > maxFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File size exceeds 20MB limit",
+		})
+	}
+
+	opts := services.PlayerImportOptions{
+		DryRun: c.FormValue("dry_run") == "true" || c.FormValue("dry_run") == "1",
+	}
+	if mapping := c.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &opts.Mapping); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "mapping must be a JSON object of field to column header",
+			})
+		}
+	}
+	if statsColumns := strings.TrimSpace(c.FormValue("stats_columns")); statsColumns != "" {
+		if strings.HasPrefix(statsColumns, "[") {
+			if err := json.Unmarshal([]byte(statsColumns), &opts.StatsColumns); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "stats_columns must be a JSON array or comma-separated list",
+				})
+			}
+		} else {
+			for _, column := range strings.Split(statsColumns, ",") {
+				if column = strings.TrimSpace(column); column != "" {
+					opts.StatsColumns = append(opts.StatsColumns, column)
+				}
+			}
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to read uploaded file",
+		})
+	}
+	defer src.Close()
+
+	result, err := h.services.Players.Import(c.Context(), file.Filename, src, opts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Nothing was imported if any row failed validation
+	if !result.DryRun && len(result.Errors) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+	if result.Imported > 0 {
+		h.services.Auction.InvalidateState(c.Context())
+		h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeQueue|websocket.ChangeTeams)
+	}
+	return c.JSON(result)
 }
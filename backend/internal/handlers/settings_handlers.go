@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
+
+	"github.com/auctionapp/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
 )
 
 // GetSettings returns all settings
 func (h *Handlers) GetSettings(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	settings, err := h.services.Settings.GetAll(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -17,6 +21,7 @@ func (h *Handlers) GetSettings(c *fiber.Ctx) error {
 
 // UpdateSettings updates settings
 func (h *Handlers) UpdateSettings(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var settings map[string]interface{}
 	if err := c.BodyParser(&settings); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -25,15 +30,33 @@ func (h *Handlers) UpdateSettings(c *fiber.Ctx) error {
 	}
 
 	if err := h.services.Settings.Update(c.Context(), settings); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSettings) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+
+	// The ladder and squad rules feed the live state
+	h.services.Auction.InvalidateState(c.Context())
+
+	// Let clients rebuild their bid buttons when the ladder changes
+	if _, ok := settings[services.BidIncrementsKey]; ok {
+		ladder := h.services.Auction.BidLadder(c.Context())
+		h.hub.BroadcastAll("auction:bid-ladder", fiber.Map{
+			"ladder":  ladder,
+			"max_bid": ladder.Max(),
+		})
+	}
+
 	return c.JSON(fiber.Map{"message": "Settings updated"})
 }
 
 // GetOverviewStats returns dashboard stats
 func (h *Handlers) GetOverviewStats(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	stats, err := h.services.Stats.GetOverview(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -45,6 +68,7 @@ func (h *Handlers) GetOverviewStats(c *fiber.Ctx) error {
 
 // GetTopBuys returns top purchases
 func (h *Handlers) GetTopBuys(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	limit := c.QueryInt("limit", 10)
 	players, err := h.services.Players.GetTopBuys(c.Context(), limit)
 	if err != nil {
@@ -57,6 +81,7 @@ func (h *Handlers) GetTopBuys(c *fiber.Ctx) error {
 
 // GetRecentSales returns recent sales
 func (h *Handlers) GetRecentSales(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	limit := c.QueryInt("limit", 10)
 	players, err := h.services.Players.GetRecentSales(c.Context(), limit)
 	if err != nil {
@@ -69,6 +94,7 @@ func (h *Handlers) GetRecentSales(c *fiber.Ctx) error {
 
 // GetStreamUrl returns the current YouTube stream URL (public endpoint)
 func (h *Handlers) GetStreamUrl(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	url, err := h.services.Settings.Get(c.Context(), "youtube_stream_url")
 	if err != nil {
 		// Return empty string if not set
@@ -83,6 +109,7 @@ func (h *Handlers) GetStreamUrl(c *fiber.Ctx) error {
 
 // SetStreamUrl sets the YouTube stream URL and broadcasts to all viewers
 func (h *Handlers) SetStreamUrl(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var body struct {
 		Url string `json:"url"`
 	}
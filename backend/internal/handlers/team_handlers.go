@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 // GetTeams returns all teams
 func (h *Handlers) GetTeams(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	teams, err := h.services.Teams.GetAll(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -19,6 +21,7 @@ func (h *Handlers) GetTeams(c *fiber.Ctx) error {
 
 // GetTeam returns a team by ID
 func (h *Handlers) GetTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -37,6 +40,7 @@ func (h *Handlers) GetTeam(c *fiber.Ctx) error {
 
 // GetTeamSquad returns a team's players
 func (h *Handlers) GetTeamSquad(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -55,6 +59,7 @@ func (h *Handlers) GetTeamSquad(c *fiber.Ctx) error {
 
 // CreateTeam creates a new team
 func (h *Handlers) CreateTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	var req models.CreateTeamRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -68,11 +73,13 @@ func (h *Handlers) CreateTeam(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.Status(fiber.StatusCreated).JSON(team)
 }
 
 // UpdateTeam updates a team
 func (h *Handlers) UpdateTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -93,11 +100,13 @@ func (h *Handlers) UpdateTeam(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.JSON(team)
 }
 
 // DeleteTeam deletes a team
 func (h *Handlers) DeleteTeam(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -110,11 +119,13 @@ func (h *Handlers) DeleteTeam(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	h.services.Auction.InvalidateState(c.Context())
 	return c.JSON(fiber.Map{"message": "Team deleted"})
 }
 
-// RetainPlayers retains players to a team with optional badges
+// RetainPlayers replaces a team's retained players, charging their cost to its purse
 func (h *Handlers) RetainPlayers(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -130,10 +141,13 @@ func (h *Handlers) RetainPlayers(c *fiber.Ctx) error {
 	}
 
 	if err := h.services.Teams.RetainPlayers(c.Context(), id, req.Players); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	// Retained players count towards the squad and their cost comes out of the purse
+	h.services.Auction.InvalidateState(c.Context())
+	h.hub.BroadcastChanges(c.Context(), h.services, websocket.ChangeTeams|websocket.ChangeQueue)
 
 	// Return updated retained players
 	players, err := h.services.Teams.GetRetainedPlayers(c.Context(), id)
@@ -147,6 +161,7 @@ func (h *Handlers) RetainPlayers(c *fiber.Ctx) error {
 
 // GetRetainedPlayers returns all retained players for a team
 func (h *Handlers) GetRetainedPlayers(c *fiber.Ctx) error {
+	h = h.scoped(c)
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
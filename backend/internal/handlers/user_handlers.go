@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-// GetUsers returns all users
+// GetUsers returns all users of the organisation
 func (h *Handlers) GetUsers(c *fiber.Ctx) error {
-	users, err := h.services.Users.GetAll(c.Context())
+	users, err := h.tenant(c).Users.GetAll(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -26,7 +29,7 @@ func (h *Handlers) GetUser(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.services.Users.GetByID(c.Context(), id)
+	user, err := h.tenant(c).Users.GetByID(c.Context(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "User not found",
@@ -35,7 +38,7 @@ func (h *Handlers) GetUser(c *fiber.Ctx) error {
 	return c.JSON(user)
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user in the organisation
 func (h *Handlers) CreateUser(c *fiber.Ctx) error {
 	var req models.CreateUserRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -43,8 +46,13 @@ func (h *Handlers) CreateUser(c *fiber.Ctx) error {
 			"error": "Invalid request body",
 		})
 	}
+	if !canAssignRole(c, req.Role) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Insufficient permissions",
+		})
+	}
 
-	user, err := h.services.Users.Create(c.Context(), req)
+	user, err := h.tenant(c).Users.Create(c.Context(), req)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -69,12 +77,30 @@ func (h *Handlers) UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.services.Users.Update(c.Context(), id, req)
+	users := h.tenant(c).Users
+	current, err := users.GetByID(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	if !canAssignRole(c, current.Role) || (req.Role != nil && !canAssignRole(c, *req.Role)) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Insufficient permissions",
+		})
+	}
+
+	user, err := users.Update(c.Context(), id, req)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+
+	// A suspended (or pending) user must not stay connected to the live auction
+	if user.Status != "active" {
+		h.hub.DisconnectUser(c.Context(), user.ID, "Account "+user.Status)
+	}
 	return c.JSON(user)
 }
 
@@ -87,10 +113,26 @@ func (h *Handlers) DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.services.Users.Delete(c.Context(), id); err != nil {
+	if err := h.tenant(c).Users.Delete(c.Context(), id); err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	h.hub.DisconnectUser(c.Context(), id, "Account deleted")
 	return c.JSON(fiber.Map{"message": "User deleted"})
 }
+
+// canAssignRole reports whether the signed-in user may give (or change the account of
+// a user with) role: only org admins and super admins manage org admins
+func canAssignRole(c *fiber.Ctx, role string) bool {
+	if role != "org_admin" && role != "super_admin" {
+		return true
+	}
+	userRole, _ := c.Locals("role").(string)
+	return userRole == "super_admin" || (userRole == "org_admin" && role == "org_admin")
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/auctionapp/backend/internal/models"
 	"github.com/auctionapp/backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -59,12 +61,15 @@ func JWTAuth(secret string, redisClient *redis.Client) fiber.Handler {
 		c.Locals("email", claims.Email)
 		c.Locals("role", claims.Role)
 		c.Locals("teamID", claims.TeamID)
+		c.Locals("orgID", claims.OrgID)
+		c.Locals("token", token) // for exchanging into a WebSocket ticket
 
 		return c.Next()
 	}
 }
 
-// RequireRole middleware checks if user has required role
+// RequireRole middleware checks if user has required role. Roles only count within the
+// user's organisation: a request for another organisation's auction is refused.
 func RequireRole(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userRole, ok := c.Locals("role").(string)
@@ -74,8 +79,17 @@ func RequireRole(roles ...string) fiber.Handler {
 			})
 		}
 
-		// Super admin can access everything
-		if userRole == "super_admin" {
+		orgID, _ := c.Locals("orgID").(*uuid.UUID)
+		if auction, ok := c.Locals("auction").(*models.Auction); ok && orgID != nil {
+			if auction.OrganizationID == nil || *auction.OrganizationID != *orgID {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": "Insufficient permissions",
+				})
+			}
+		}
+
+		// Super admin can access everything, and an org admin everything in its organisation
+		if userRole == "super_admin" || userRole == "org_admin" {
 			return c.Next()
 		}
 
@@ -92,6 +106,18 @@ func RequireRole(roles ...string) fiber.Handler {
 	}
 }
 
+// RequirePlatformAdmin middleware only lets through super admins who are not part of an
+// organisation, i.e. those running the platform
+func RequirePlatformAdmin(c *fiber.Ctx) error {
+	userRole, _ := c.Locals("role").(string)
+	if orgID, _ := c.Locals("orgID").(*uuid.UUID); userRole != "super_admin" || orgID != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Insufficient permissions",
+		})
+	}
+	return c.Next()
+}
+
 // RateLimit creates a Redis-based rate limiting middleware
 // maxRequests: maximum number of requests allowed in the window
 // windowSeconds: time window in seconds
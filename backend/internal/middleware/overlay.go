@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OverlayToken middleware guards an auction's broadcast overlay routes with the auction's
+// own token, which tokenOf returns for the auction the request resolved to. Browser
+// sources in OBS/vMix can only load a URL, so the token is accepted from ?token= as well
+// as the X-Overlay-Token header.
+func OverlayToken(tokenOf func(c *fiber.Ctx) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := tokenOf(c)
+		if token == "" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Overlays are not configured",
+			})
+		}
+
+		given := c.Get("X-Overlay-Token")
+		if given == "" {
+			given = c.Query("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid overlay token",
+			})
+		}
+		return c.Next()
+	}
+}
@@ -14,6 +14,7 @@ type User struct {
 	Name         string     `json:"name"`
 	Role         string     `json:"role"`
 	TeamID       *uuid.UUID `json:"team_id,omitempty"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"` // nil only for platform super admins
 	Status       string     `json:"status"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
@@ -22,6 +23,15 @@ type User struct {
 	Team         *Team      `json:"team,omitempty"`
 }
 
+// Organization is an independent league hosting its own auctions
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Team represents an auction team
 type Team struct {
 	ID         uuid.UUID `json:"id"`
@@ -40,6 +50,8 @@ type Team struct {
 	RemainingBudget int64 `json:"remaining_budget,omitempty"`
 	PlayerCount     int   `json:"player_count,omitempty"`
 	ForeignCount    int   `json:"foreign_count,omitempty"`
+	MaxBid          int64 `json:"max_bid"` // Most the team can bid while reserving purse for its minimum squad
+	RTMCards        int   `json:"rtm_cards"` // Right-to-match cards left
 }
 
 // Player represents a player in the auction pool
@@ -59,6 +71,8 @@ type Player struct {
 	SoldAt      *time.Time             `json:"sold_at,omitempty"`
 	QueueOrder  *int                   `json:"queue_order,omitempty"`
 	Badge       *string                `json:"badge,omitempty"`
+	PreviousTeamID *uuid.UUID          `json:"previous_team_id,omitempty"` // Franchise that may use a right-to-match card
+	Uncapped    bool                   `json:"uncapped"`                   // Not yet capped at international level
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 
@@ -77,7 +91,10 @@ type Auction struct {
 	CurrentBidderID *uuid.UUID `json:"current_bidder_id,omitempty"`
 	TimerDuration   int        `json:"timer_duration"`
 	TimerRemaining  *int       `json:"timer_remaining,omitempty"`
+	AutoClose       bool       `json:"auto_close"` // Sell/unsold automatically when the timer expires
 	Round           int        `json:"round"`
+	OrganizationID  *uuid.UUID `json:"organization_id,omitempty"`
+	RTMTeamID       *uuid.UUID `json:"rtm_team_id,omitempty"` // Team whose right-to-match window is open
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 
@@ -94,12 +111,146 @@ type Bid struct {
 	TeamID    uuid.UUID `json:"team_id"`
 	Amount    int64     `json:"amount"`
 	BidTime   time.Time `json:"bid_time"`
+	Seq       int64     `json:"seq"` // Global acceptance order of bids
 	IsWinning bool      `json:"is_winning"`
+	Voided    bool      `json:"voided"` // Bid belongs to a sale that was reversed (kept for audit)
 
 	// Joined fields
 	Team      *Team     `json:"team,omitempty"`
 }
 
+// Sale records a completed sale and the bid that won it
+type Sale struct {
+	ID        uuid.UUID  `json:"id"`
+	AuctionID uuid.UUID  `json:"auction_id"`
+	PlayerID  uuid.UUID  `json:"player_id"`
+	TeamID    uuid.UUID  `json:"team_id"`
+	BidID     *uuid.UUID `json:"bid_id,omitempty"`
+	Amount    int64      `json:"amount"`
+	RTM       bool       `json:"rtm"` // the former team matched the winning bid
+	SoldAt    time.Time  `json:"sold_at"`
+
+	// Set when the sale is reversed
+	ReversedAt     *time.Time `json:"reversed_at,omitempty"`
+	ReversedBy     *uuid.UUID `json:"reversed_by,omitempty"`
+	ReversalReason *string    `json:"reversal_reason,omitempty"`
+}
+
+// SaleSheetRow is one line of the sale sheet export
+type SaleSheetRow struct {
+	PlayerName string
+	Country    string
+	Role       string
+	Category   string
+	TeamName   string
+	TeamShort  string
+	BasePrice  int64
+	SoldPrice  int64
+	BidCount   int
+	SoldAt     *time.Time
+}
+
+// SeasonArchive is an immutable snapshot of an auction's results, taken before a reset
+// (or on request) so past seasons can still be looked up
+type SeasonArchive struct {
+	ID             uuid.UUID              `json:"id"`
+	OrganizationID *uuid.UUID             `json:"organization_id,omitempty"`
+	AuctionID      uuid.UUID              `json:"auction_id"`
+	Name           string                 `json:"name"`
+	Season         string                 `json:"season"`
+	Status         string                 `json:"status"` // the auction's status when archived
+	Settings       map[string]interface{} `json:"settings,omitempty"`
+	TotalSpent     int64                  `json:"total_spent"`
+	PlayersSold    int                    `json:"players_sold"`
+	PlayersUnsold  int                    `json:"players_unsold"`
+	Reason         string                 `json:"reason"` // manual, reset or reset_everything
+	ArchivedBy     *uuid.UUID             `json:"archived_by,omitempty"`
+	ArchivedAt     time.Time              `json:"archived_at"`
+}
+
+// ArchivedTeam is a team's purse at archive time, with its squad
+type ArchivedTeam struct {
+	TeamID      uuid.UUID        `json:"team_id"`
+	Name        string           `json:"name"`
+	ShortName   string           `json:"short_name"`
+	Color       string           `json:"color"`
+	LogoURL     *string          `json:"logo_url,omitempty"`
+	Budget      int64            `json:"budget"`
+	Spent       int64            `json:"spent"`
+	Remaining   int64            `json:"remaining"`
+	PlayerCount int              `json:"player_count"`
+	Squad       []ArchivedPlayer `json:"squad"`
+}
+
+// ArchivedPlayer is a player as they stood at archive time
+type ArchivedPlayer struct {
+	PlayerID  uuid.UUID  `json:"player_id"`
+	Name      string     `json:"name"`
+	Country   string     `json:"country"`
+	Role      string     `json:"role"`
+	Category  *string    `json:"category,omitempty"`
+	BasePrice int64      `json:"base_price"`
+	Status    string     `json:"status"`
+	SoldPrice *int64     `json:"sold_price,omitempty"`
+	TeamID    *uuid.UUID `json:"team_id,omitempty"`
+	SoldAt    *time.Time `json:"sold_at,omitempty"`
+}
+
+// ArchivedSale is a sale at archive time, reversed ones included
+type ArchivedSale struct {
+	SaleID         uuid.UUID  `json:"sale_id"`
+	PlayerID       uuid.UUID  `json:"player_id"`
+	TeamID         uuid.UUID  `json:"team_id"`
+	BidID          *uuid.UUID `json:"bid_id,omitempty"`
+	Amount         int64      `json:"amount"`
+	RTM            bool       `json:"rtm"`
+	SoldAt         time.Time  `json:"sold_at"`
+	ReversedAt     *time.Time `json:"reversed_at,omitempty"`
+	ReversalReason *string    `json:"reversal_reason,omitempty"`
+}
+
+// ArchivedBid is a bid at archive time
+type ArchivedBid struct {
+	BidID     uuid.UUID `json:"bid_id"`
+	PlayerID  uuid.UUID `json:"player_id"`
+	TeamID    uuid.UUID `json:"team_id"`
+	Amount    int64     `json:"amount"`
+	BidTime   time.Time `json:"bid_time"`
+	Seq       int64     `json:"seq"`
+	IsWinning bool      `json:"is_winning"`
+	Voided    bool      `json:"voided"`
+}
+
+// SeasonArchiveDetail is a past season: purses and squads, unsold players and sales
+type SeasonArchiveDetail struct {
+	SeasonArchive
+	Teams  []ArchivedTeam   `json:"teams"`
+	Unsold []ArchivedPlayer `json:"unsold"` // unsold, or never on the block
+	Sales  []ArchivedSale   `json:"sales"`
+}
+
+// PlayerSeason is how a player fared in one archived season
+type PlayerSeason struct {
+	ArchiveID  uuid.UUID  `json:"archive_id"`
+	AuctionID  uuid.UUID  `json:"auction_id"`
+	Auction    string     `json:"auction"`
+	Season     string     `json:"season"`
+	ArchivedAt time.Time  `json:"archived_at"`
+	PlayerID   uuid.UUID  `json:"player_id"`
+	BasePrice  int64      `json:"base_price"`
+	Status     string     `json:"status"`
+	SoldPrice  *int64     `json:"sold_price,omitempty"`
+	TeamID     *uuid.UUID `json:"team_id,omitempty"`
+	TeamName   *string    `json:"team_name,omitempty"`
+}
+
+// PlayerHistory links one player's seasons, matched by name and country
+type PlayerHistory struct {
+	Name    string         `json:"name"`
+	Country string         `json:"country"`
+	Seasons []PlayerSeason `json:"seasons"` // newest first
+}
+
 // Setting represents a key-value setting
 type Setting struct {
 	Key       string      `json:"key"`
@@ -118,7 +269,217 @@ type AuctionState struct {
 	Teams         []Team    `json:"teams"`
 	QueueNext     []Player  `json:"queue_next"`
 	TimerRunning          bool      `json:"timer_running"`
+	TimerRemaining        int       `json:"timer_remaining"`
+	TimerDeadline         int64     `json:"timer_deadline,omitempty"` // Unix ms when the running timer expires
 	BidFrozen             bool      `json:"bid_frozen"`
 	BidderBiddingDisabled bool      `json:"bidder_bidding_disabled"`
-	TiedTeams             []Team    `json:"tied_teams,omitempty"` // Teams that bid 50000 (max) - for tie-breaking
+	TiedTeams             []Team    `json:"tied_teams,omitempty"` // Teams that bid the ladder max - for tie-breaking
+	BidLadder             []int64   `json:"bid_ladder"`           // Allowed bid amounts from the bid_increments setting
+	MaxBid                int64     `json:"max_bid"`              // Top of the ladder (tie-break ceiling)
+	Presence              *Presence `json:"presence,omitempty"`   // Who is connected; host view only
+	RTM                   *RTMOffer `json:"rtm"`                  // Open right-to-match window, if any
+}
+
+// RTMOffer is an open right-to-match window: after the hammer the player's former team may
+// take them at the winning bid with one of its cards, or let the winning bidder have them
+type RTMOffer struct {
+	Player      *Player `json:"player"`
+	Team        *Team   `json:"team"`         // the former team holding the card
+	WinningTeam *Team   `json:"winning_team"` // the team that won the bidding
+	Amount      int64   `json:"amount"`
+	CardsLeft   int     `json:"cards_left"` // the former team's cards, this one included
+	Window      int     `json:"window"`     // seconds the former team has to answer
+}
+
+// State patches are partial AuctionStates: a client applies one by overwriting the fields it
+// carries, except where noted. Only clients that connect with ?patches=1 receive them.
+
+// StatusPatch carries the auction status and host switches (patch:status)
+type StatusPatch struct {
+	Auction               *Auction `json:"auction"`
+	Status                string   `json:"status"`
+	BidderBiddingDisabled bool     `json:"bidder_bidding_disabled"`
+	TimerRunning          bool     `json:"timer_running"`
+	TimerRemaining        int      `json:"timer_remaining"`
+	TimerDeadline         int64    `json:"timer_deadline,omitempty"`
+}
+
+// LotPatch carries the player under the hammer and its bidding (patch:lot-opened,
+// patch:lot-closed, patch:lot-updated). A closed lot has no current player and no bids.
+type LotPatch struct {
+	Auction        *Auction `json:"auction"`
+	CurrentPlayer  *Player  `json:"current_player"`
+	CurrentBidder  *Team    `json:"current_bidder"`
+	CurrentBid     *int64   `json:"current_bid"`
+	BidHistory     []Bid    `json:"bids"`
+	TiedTeams      []Team   `json:"tied_teams"`
+	TimerRunning   bool     `json:"timer_running"`
+	TimerRemaining int      `json:"timer_remaining"`
+	TimerDeadline  int64    `json:"timer_deadline,omitempty"`
+	BidFrozen      bool     `json:"bid_frozen"`
+	RTM            *RTMOffer `json:"rtm"`
+}
+
+// BidPatch carries one accepted bid (patch:bid-added). Bid is prepended to the state's bids
+// rather than replacing them.
+type BidPatch struct {
+	Bid            *Bid     `json:"bid"`
+	Auction        *Auction `json:"auction"`
+	CurrentBidder  *Team    `json:"current_bidder"`
+	CurrentBid     *int64   `json:"current_bid"`
+	TiedTeams      []Team   `json:"tied_teams"`
+	TimerRunning   bool     `json:"timer_running"`
+	TimerRemaining int      `json:"timer_remaining"`
+	TimerDeadline  int64    `json:"timer_deadline,omitempty"`
+	BidFrozen      bool     `json:"bid_frozen"`
+}
+
+// TeamPurse is the part of a team that changes during the auction
+type TeamPurse struct {
+	ID              uuid.UUID `json:"id"`
+	Spent           int64     `json:"spent"`
+	RemainingBudget int64     `json:"remaining_budget"`
+	PlayerCount     int       `json:"player_count"`
+	ForeignCount    int       `json:"foreign_count"`
+	MaxBid          int64     `json:"max_bid"`
+	RTMCards        int       `json:"rtm_cards"`
+}
+
+// TeamsPatch carries every team's purse (patch:team-purses). Each entry is merged into the
+// state's team with the same id.
+type TeamsPatch struct {
+	Teams []TeamPurse `json:"teams"`
+}
+
+// QueuePatch carries the next players up (patch:queue)
+type QueuePatch struct {
+	QueueNext []Player `json:"queue_next"`
+}
+
+// Overlay payloads are ready to draw on broadcast graphics (OBS/vMix browser sources):
+// amounts come with a display string and teams with their colour and logo.
+
+// OverlayTeam is a team as shown on a graphic
+type OverlayTeam struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ShortName string    `json:"short_name"`
+	Color     string    `json:"color"`
+	LogoURL   *string   `json:"logo_url,omitempty"`
+}
+
+// OverlayPlayer is a player as shown on a graphic
+type OverlayPlayer struct {
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	Country          string    `json:"country"`
+	CountryFlag      string    `json:"country_flag"`
+	Role             string    `json:"role"`
+	Category         string    `json:"category"`
+	ImageURL         *string   `json:"image_url,omitempty"`
+	BasePrice        int64     `json:"base_price"`
+	BasePriceDisplay string    `json:"base_price_display"`
+}
+
+// OverlayLotCard is the player under the hammer and the bid they are on
+type OverlayLotCard struct {
+	Player            OverlayPlayer `json:"player"`
+	CurrentBid        int64         `json:"current_bid"`
+	CurrentBidDisplay string        `json:"current_bid_display"`
+	Leader            *OverlayTeam  `json:"leader"` // nil until someone bids
+	BidCount          int           `json:"bid_count"`
+	TimerRunning      bool          `json:"timer_running"`
+	TimerDeadline     int64         `json:"timer_deadline,omitempty"` // unix ms
+}
+
+// OverlayBid is one line of the bid ticker
+type OverlayBid struct {
+	Amount        int64       `json:"amount"`
+	AmountDisplay string      `json:"amount_display"`
+	Team          OverlayTeam `json:"team"`
+	BidTime       time.Time   `json:"bid_time"`
+}
+
+// OverlaySoldCard is the "SOLD" graphic
+type OverlaySoldCard struct {
+	Player        OverlayPlayer `json:"player"`
+	Team          OverlayTeam   `json:"team"`
+	Amount        int64         `json:"amount"`
+	AmountDisplay string        `json:"amount_display"`
+}
+
+// OverlayPurse is one row of the team purse table
+type OverlayPurse struct {
+	Team             OverlayTeam `json:"team"`
+	Remaining        int64       `json:"remaining"`
+	RemainingDisplay string      `json:"remaining_display"`
+	MaxBid           int64       `json:"max_bid"`
+	MaxBidDisplay    string      `json:"max_bid_display"`
+	Players          int         `json:"players"`
+	MaxPlayers       int         `json:"max_players"`
+}
+
+// OverlayFrame is every graphic at once, sent when an overlay connects
+type OverlayFrame struct {
+	Status string          `json:"status"`
+	Lot    *OverlayLotCard `json:"lot"` // nil between lots
+	Bids   []OverlayBid    `json:"bids"`
+	Purses []OverlayPurse  `json:"purses"`
+	NextUp []OverlayPlayer `json:"next_up"`
+}
+
+// Presence is who is connected to the live auction, across every server node
+type Presence struct {
+	Teams []TeamPresence `json:"teams"`
+	Roles []RolePresence `json:"roles"`
+	Users []UserPresence `json:"users"`
+}
+
+// UserPresence is one signed-in user's connection status.
+// LastSeen is now while they are online, else when their last connection closed.
+type UserPresence struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	Role        string     `json:"role"`
+	TeamID      *uuid.UUID `json:"team_id,omitempty"`
+	Online      bool       `json:"online"`
+	Connections int        `json:"connections"`
+	LastSeen    *time.Time `json:"last_seen"` // nil if never connected
+}
+
+// TeamPresence is whether anyone from a franchise (normally its bidder) is connected
+type TeamPresence struct {
+	TeamID      uuid.UUID  `json:"team_id"`
+	Online      bool       `json:"online"`
+	Connections int        `json:"connections"`
+	LastSeen    *time.Time `json:"last_seen"`
+}
+
+// RolePresence counts the connections of one role. Viewers and overlays are anonymous,
+// so only their connections are counted.
+type RolePresence struct {
+	Role        string     `json:"role"`
+	Online      int        `json:"online"` // signed-in users with at least one connection
+	Connections int        `json:"connections"`
+	LastSeen    *time.Time `json:"last_seen"`
+}
+
+// PresenceEvent is sent to hosts and admins as presence:join or presence:leave
+type PresenceEvent struct {
+	User UserPresence  `json:"user"`
+	Team *TeamPresence `json:"team,omitempty"` // the user's franchise, if they bid for one
+}
+
+// ConnectionInfo is one live WebSocket or event-stream connection, as listed to admins
+type ConnectionInfo struct {
+	ID            uuid.UUID  `json:"id"`
+	Node          string     `json:"node,omitempty"` // server node holding the connection
+	UserID        *uuid.UUID `json:"user_id"`        // nil for public viewers and overlays
+	Role          string     `json:"role"`
+	TeamID        *uuid.UUID `json:"team_id,omitempty"`
+	AuctionID     uuid.UUID  `json:"auction_id"`
+	RemoteIP      string     `json:"remote_ip"`
+	Transport     string     `json:"transport"` // "websocket" or "sse"
+	ConnectedAt   time.Time  `json:"connected_at"`
+	QueueDepth    int        `json:"queue_depth"` // messages waiting to be written
+	QueueCapacity int        `json:"queue_capacity"`
 }
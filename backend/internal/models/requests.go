@@ -19,19 +19,54 @@ type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
 	Name     string `json:"name" validate:"required"`
-	Role     string `json:"role" validate:"required,oneof=admin host bidder viewer"`
+	Role     string `json:"role" validate:"required,oneof=org_admin admin host bidder viewer"`
 	TeamID   string `json:"team_id,omitempty"`
+
+	// Only platform super admins choose the organisation; everyone else creates users in their own
+	OrganizationID string `json:"organization_id,omitempty"`
 }
 
 // UpdateUserRequest for updating users
 type UpdateUserRequest struct {
 	Email  *string `json:"email,omitempty" validate:"omitempty,email"`
 	Name   *string `json:"name,omitempty"`
-	Role   *string `json:"role,omitempty" validate:"omitempty,oneof=admin host bidder viewer"`
+	Role   *string `json:"role,omitempty" validate:"omitempty,oneof=org_admin admin host bidder viewer"`
 	Status *string `json:"status,omitempty" validate:"omitempty,oneof=active pending suspended"`
 	TeamID *string `json:"team_id,omitempty"`
 }
 
+// CreateAuctionRequest for creating auctions (one per tournament)
+// Season, timer duration and auto-close default to the settings
+type CreateAuctionRequest struct {
+	Name          string  `json:"name" validate:"required"`
+	Season        string  `json:"season,omitempty"`
+	TimerDuration *int    `json:"timer_duration,omitempty"`
+	AutoClose     *bool   `json:"auto_close,omitempty"`
+
+	// Only platform super admins choose the organisation; everyone else creates auctions in their own
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+// UpdateAuctionRequest for updating auctions
+type UpdateAuctionRequest struct {
+	Name          *string `json:"name,omitempty"`
+	Season        *string `json:"season,omitempty"`
+	TimerDuration *int    `json:"timer_duration,omitempty"`
+	AutoClose     *bool   `json:"auto_close,omitempty"`
+}
+
+// CreateOrganizationRequest for creating organisations
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required"`
+	Slug string `json:"slug" validate:"required"`
+}
+
+// UpdateOrganizationRequest for updating organisations
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name,omitempty"`
+	Slug *string `json:"slug,omitempty"`
+}
+
 // CreateTeamRequest for creating teams
 type CreateTeamRequest struct {
 	Name       string `json:"name" validate:"required"`
@@ -65,6 +100,8 @@ type CreatePlayerRequest struct {
 	ImageURL    string                 `json:"image_url,omitempty"`
 	Stats       map[string]interface{} `json:"stats,omitempty"`
 	QueueOrder  *int                   `json:"queue_order,omitempty"`
+	PreviousTeamID string              `json:"previous_team_id,omitempty"` // Team holding the player's right to match
+	Uncapped    bool                   `json:"uncapped,omitempty"`
 }
 
 // UpdatePlayerRequest for updating players
@@ -79,6 +116,8 @@ type UpdatePlayerRequest struct {
 	Stats       map[string]interface{} `json:"stats,omitempty"`
 	Status      *string                `json:"status,omitempty"`
 	QueueOrder  *int                   `json:"queue_order,omitempty"`
+	PreviousTeamID *string             `json:"previous_team_id,omitempty"` // "" clears it
+	Uncapped    *bool                  `json:"uncapped,omitempty"`
 }
 
 // PlaceBidRequest for placing a bid
@@ -86,12 +125,44 @@ type PlaceBidRequest struct {
 	Amount int64 `json:"amount" validate:"required,gt=0"`
 }
 
+// RTMResponseRequest answers a right-to-match window
+type RTMResponseRequest struct {
+	Accept bool `json:"accept"`
+}
+
 // PlaceBidForTeamRequest for host/admin to place bid on behalf of a team
 type PlaceBidForTeamRequest struct {
 	TeamID string `json:"team_id" validate:"required"`
 	Amount int64  `json:"amount" validate:"required,gt=0"`
 }
 
+// UnsellRequest for reversing a completed sale
+// Mode is "queue" (default) to return the player to the end of the queue or "block" to re-open bidding now
+type UnsellRequest struct {
+	Mode   string `json:"mode"`
+	Reason string `json:"reason"`
+}
+
+// ImportRowError describes why a row of a player import was rejected
+// Row is the spreadsheet row number the error was found on
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// PlayerImportResult is the outcome of a player import or dry run
+type PlayerImportResult struct {
+	DryRun       bool              `json:"dry_run"`
+	TotalRows    int               `json:"total_rows"`
+	ValidRows    int               `json:"valid_rows"`
+	Imported     int               `json:"imported"`
+	Mapping      map[string]string `json:"mapping"`
+	StatsColumns []string          `json:"stats_columns"`
+	Errors       []ImportRowError  `json:"errors"`
+	Players      []Player          `json:"players"`
+}
+
 // PlayerFilter for filtering players
 type PlayerFilter struct {
 	Status   string `query:"status"`
@@ -129,7 +200,8 @@ type RetainedPlayer struct {
 	Badge    string `json:"badge,omitempty"`
 }
 
-// RetainPlayersRequest for retaining players to a team
+// RetainPlayersRequest for retaining players to a team. The list replaces the team's
+// retentions; its order decides which price slab each retention costs.
 type RetainPlayersRequest struct {
 	Players []RetainedPlayer `json:"players" validate:"required"`
 }
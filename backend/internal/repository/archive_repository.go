@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// identityKey is the expression that recognises a player across seasons (and so across
+// auctions, where the player rows differ): lower-cased, trimmed name and country
+func identityKey(name, country string) string {
+	return "LOWER(TRIM(" + name + ")) || '|' || LOWER(TRIM(" + country + "))"
+}
+
+// ArchiveRepository handles season archive database operations. Archives are written
+// once by Snapshot and never changed; bound to an organisation with ForOrganization it
+// only reads that organisation's archives.
+type ArchiveRepository struct {
+	db  Querier
+	org *uuid.UUID // nil for every organisation's archives
+}
+
+// NewArchiveRepository creates a new archive repository
+func NewArchiveRepository(db *pgxpool.Pool) *ArchiveRepository {
+	return &ArchiveRepository{db: db}
+}
+
+const archiveColumns = `a.id, a.organization_id, a.auction_id, a.name, a.season, a.status, a.settings,
+		   a.total_spent, a.players_sold, a.players_unsold, a.reason, a.archived_by, a.archived_at`
+
+// Snapshot copies an auction's settings, purses, players, sales and bids into a new
+// archive. Run it inside WithTx so the archive is complete or not there at all.
+func (r *ArchiveRepository) Snapshot(ctx context.Context, auctionID uuid.UUID, reason string, archivedBy *uuid.UUID) (*models.SeasonArchive, error) {
+	archive, err := r.scanOne(ctx, `
+		WITH a AS (
+			INSERT INTO season_archives (organization_id, auction_id, name, season, status, settings,
+				total_spent, players_sold, players_unsold, reason, archived_by)
+			SELECT au.organization_id, au.id, au.name, au.season, COALESCE(au.status, 'pending'),
+				COALESCE((
+					SELECT jsonb_object_agg(s.key, s.value) FROM (
+						SELECT DISTINCT ON (key) key, value FROM settings
+						WHERE auction_id IS NULL OR auction_id = au.id
+						ORDER BY key, auction_id NULLS LAST
+					) s
+				), '{}'),
+				COALESCE((SELECT SUM(spent) FROM teams WHERE auction_id = au.id), 0),
+				(SELECT COUNT(*) FROM players WHERE auction_id = au.id AND status = 'sold'),
+				(SELECT COUNT(*) FROM players WHERE auction_id = au.id AND status = 'unsold'),
+				$2, $3
+			FROM auctions au
+			WHERE au.id = $1 AND `+inOrganization("au.organization_id", 4)+`
+			RETURNING *
+		)
+		SELECT `+archiveColumns+` FROM a
+	`, auctionID, reason, archivedBy, r.org)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.db.Exec(ctx, `
+		INSERT INTO archived_teams (archive_id, team_id, name, short_name, color, logo_url, budget, spent, player_count)
+		SELECT $1, t.id, t.name, t.short_name, t.color, t.logo_url, t.budget, t.spent,
+			   (SELECT COUNT(*) FROM players p WHERE p.team_id = t.id AND (p.status = 'sold' OR p.status = 'retained'))
+		FROM teams t WHERE t.auction_id = $2
+	`, archive.ID, auctionID); err != nil {
+		return nil, err
+	}
+	if _, err := r.db.Exec(ctx, `
+		INSERT INTO archived_players (archive_id, player_id, identity_key, name, country, role, category,
+			base_price, status, sold_price, team_id, sold_at)
+		SELECT $1, p.id, `+identityKey("p.name", "p.country")+`, p.name, p.country, p.role, p.category,
+			   p.base_price, COALESCE(p.status, 'available'), p.sold_price, p.team_id, p.sold_at
+		FROM players p WHERE p.auction_id = $2
+	`, archive.ID, auctionID); err != nil {
+		return nil, err
+	}
+	if _, err := r.db.Exec(ctx, `
+		INSERT INTO archived_sales (archive_id, sale_id, player_id, team_id, bid_id, amount, rtm, sold_at, reversed_at, reversal_reason)
+		SELECT $1, id, player_id, team_id, bid_id, amount, rtm, COALESCE(sold_at, NOW()), reversed_at, reversal_reason
+		FROM sales WHERE auction_id = $2 AND player_id IS NOT NULL AND team_id IS NOT NULL
+	`, archive.ID, auctionID); err != nil {
+		return nil, err
+	}
+	if _, err := r.db.Exec(ctx, `
+		INSERT INTO archived_bids (archive_id, bid_id, player_id, team_id, amount, bid_time, seq, is_winning, voided)
+		SELECT $1, id, player_id, team_id, amount, COALESCE(bid_time, NOW()), seq, COALESCE(is_winning, FALSE), voided
+		FROM bids WHERE auction_id = $2 AND player_id IS NOT NULL AND team_id IS NOT NULL
+	`, archive.ID, auctionID); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// HasUnarchivedActivity reports whether an auction has bids, sales or decided players
+// newer than its latest archive, i.e. whether a reset would lose anything
+func (r *ArchiveRepository) HasUnarchivedActivity(ctx context.Context, auctionID uuid.UUID) (bool, error) {
+	var pending bool
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(GREATEST(
+			(SELECT MAX(bid_time) FROM bids WHERE auction_id = $1),
+			(SELECT MAX(GREATEST(sold_at, reversed_at)) FROM sales WHERE auction_id = $1),
+			(SELECT MAX(updated_at) FROM players WHERE auction_id = $1 AND status <> 'available')
+		) > COALESCE((SELECT MAX(archived_at) FROM season_archives WHERE auction_id = $1), '-infinity'), FALSE)
+	`, auctionID).Scan(&pending)
+	return pending, err
+}
+
+// List returns archives newest first, only those of one auction when auctionID is set
+func (r *ArchiveRepository) List(ctx context.Context, auctionID *uuid.UUID) ([]models.SeasonArchive, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+archiveColumns+`
+		FROM season_archives a
+		WHERE `+inOrganization("a.organization_id", 1)+` AND ($2::uuid IS NULL OR a.auction_id = $2)
+		ORDER BY a.archived_at DESC
+	`, r.org, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	archives := []models.SeasonArchive{}
+	for rows.Next() {
+		a, err := scanArchive(rows)
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, *a)
+	}
+	return archives, rows.Err()
+}
+
+// FindByID finds an archive by ID
+func (r *ArchiveRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.SeasonArchive, error) {
+	return r.scanOne(ctx, `
+		SELECT `+archiveColumns+`
+		FROM season_archives a
+		WHERE a.id = $1 AND `+inOrganization("a.organization_id", 2)+`
+	`, id, r.org)
+}
+
+// FindTeams returns an archive's teams by name, without their squads
+func (r *ArchiveRepository) FindTeams(ctx context.Context, archiveID uuid.UUID) ([]models.ArchivedTeam, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT team_id, name, short_name, color, logo_url, budget, spent, player_count
+		FROM archived_teams WHERE archive_id = $1
+		ORDER BY name
+	`, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []models.ArchivedTeam{}
+	for rows.Next() {
+		var t models.ArchivedTeam
+		if err := rows.Scan(&t.TeamID, &t.Name, &t.ShortName, &t.Color, &t.LogoURL, &t.Budget, &t.Spent, &t.PlayerCount); err != nil {
+			return nil, err
+		}
+		t.Remaining = t.Budget - t.Spent
+		t.Squad = []models.ArchivedPlayer{}
+		teams = append(teams, t)
+	}
+	return teams, rows.Err()
+}
+
+// FindPlayers returns an archive's players, most expensive first
+func (r *ArchiveRepository) FindPlayers(ctx context.Context, archiveID uuid.UUID) ([]models.ArchivedPlayer, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT player_id, name, country, role, category, base_price, status, sold_price, team_id, sold_at
+		FROM archived_players WHERE archive_id = $1
+		ORDER BY sold_price DESC NULLS LAST, base_price DESC, name
+	`, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	players := []models.ArchivedPlayer{}
+	for rows.Next() {
+		var p models.ArchivedPlayer
+		if err := rows.Scan(&p.PlayerID, &p.Name, &p.Country, &p.Role, &p.Category, &p.BasePrice,
+			&p.Status, &p.SoldPrice, &p.TeamID, &p.SoldAt); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// FindSales returns an archive's sales in the order they happened
+func (r *ArchiveRepository) FindSales(ctx context.Context, archiveID uuid.UUID) ([]models.ArchivedSale, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT sale_id, player_id, team_id, bid_id, amount, rtm, sold_at, reversed_at, reversal_reason
+		FROM archived_sales WHERE archive_id = $1
+		ORDER BY sold_at
+	`, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := []models.ArchivedSale{}
+	for rows.Next() {
+		var s models.ArchivedSale
+		if err := rows.Scan(&s.SaleID, &s.PlayerID, &s.TeamID, &s.BidID, &s.Amount, &s.RTM, &s.SoldAt,
+			&s.ReversedAt, &s.ReversalReason); err != nil {
+			return nil, err
+		}
+		sales = append(sales, s)
+	}
+	return sales, rows.Err()
+}
+
+// FindBids returns an archive's bids in acceptance order, only one player's when playerID is set
+func (r *ArchiveRepository) FindBids(ctx context.Context, archiveID uuid.UUID, playerID *uuid.UUID) ([]models.ArchivedBid, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT bid_id, player_id, team_id, amount, bid_time, seq, is_winning, voided
+		FROM archived_bids
+		WHERE archive_id = $1 AND ($2::uuid IS NULL OR player_id = $2)
+		ORDER BY seq
+	`, archiveID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bids := []models.ArchivedBid{}
+	for rows.Next() {
+		var b models.ArchivedBid
+		if err := rows.Scan(&b.BidID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &b.Seq,
+			&b.IsWinning, &b.Voided); err != nil {
+			return nil, err
+		}
+		bids = append(bids, b)
+	}
+	return bids, rows.Err()
+}
+
+// FindPlayerSeasons returns every archived season of the player with this name and
+// country, newest first. A season archived more than once (say, before two resets)
+// appears once per archive.
+func (r *ArchiveRepository) FindPlayerSeasons(ctx context.Context, name, country string) ([]models.PlayerSeason, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.id, a.auction_id, a.name, a.season, a.archived_at,
+			   p.player_id, p.base_price, p.status, p.sold_price, p.team_id, t.name
+		FROM archived_players p
+		JOIN season_archives a ON a.id = p.archive_id
+		LEFT JOIN archived_teams t ON t.archive_id = p.archive_id AND t.team_id = p.team_id
+		WHERE p.identity_key = `+identityKey("$1::text", "$2::text")+`
+		  AND `+inOrganization("a.organization_id", 3)+`
+		ORDER BY a.archived_at DESC
+	`, name, country, r.org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons := []models.PlayerSeason{}
+	for rows.Next() {
+		var s models.PlayerSeason
+		if err := rows.Scan(&s.ArchiveID, &s.AuctionID, &s.Auction, &s.Season, &s.ArchivedAt,
+			&s.PlayerID, &s.BasePrice, &s.Status, &s.SoldPrice, &s.TeamID, &s.TeamName); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, s)
+	}
+	return seasons, rows.Err()
+}
+
+func (r *ArchiveRepository) scanOne(ctx context.Context, query string, args ...interface{}) (*models.SeasonArchive, error) {
+	return scanArchive(r.db.QueryRow(ctx, query, args...))
+}
+
+// scanArchive scans archiveColumns
+func scanArchive(row interface{ Scan(dest ...any) error }) (*models.SeasonArchive, error) {
+	a := &models.SeasonArchive{}
+	var settingsJSON []byte
+	err := row.Scan(&a.ID, &a.OrganizationID, &a.AuctionID, &a.Name, &a.Season, &a.Status, &settingsJSON,
+		&a.TotalSpent, &a.PlayersSold, &a.PlayersUnsold, &a.Reason, &a.ArchivedBy, &a.ArchivedAt)
+	if err != nil {
+		return nil, err
+	}
+	json.Unmarshal(settingsJSON, &a.Settings)
+	return a, nil
+}
@@ -10,7 +10,9 @@ import (
 
 // AuctionRepository handles auction database operations
 type AuctionRepository struct {
-	db *pgxpool.Pool
+	db      Querier
+	auction *uuid.UUID // when set, the current auction is always this one
+	org     *uuid.UUID // when set, only this organisation's auctions are found
 }
 
 // NewAuctionRepository creates a new auction repository
@@ -18,19 +20,44 @@ func NewAuctionRepository(db *pgxpool.Pool) *AuctionRepository {
 	return &AuctionRepository{db: db}
 }
 
-// GetCurrent returns the current/active auction
+// GetCurrent returns the current/active auction: the bound auction whatever its status,
+// else the latest one not completed. A bound auction may therefore be completed, and
+// callers changing its status must check for that.
 func (r *AuctionRepository) GetCurrent(ctx context.Context) (*models.Auction, error) {
 	a := &models.Auction{}
 	err := r.db.QueryRow(ctx, `
 		SELECT id, name, season, status, current_player_id, current_bid, current_bidder_id,
-			   timer_duration, timer_remaining, round, created_at, updated_at
+			   timer_duration, timer_remaining, auto_close, round, organization_id, rtm_team_id, created_at, updated_at
 		FROM auctions
-		WHERE status IN ('live', 'paused', 'pending')
+		WHERE id = $1 OR ($1::uuid IS NULL AND status IN ('live', 'paused', 'pending'))
 		ORDER BY created_at DESC
 		LIMIT 1
-	`).Scan(
+	`, r.auction).Scan(
 		&a.ID, &a.Name, &a.Season, &a.Status, &a.CurrentPlayerID, &a.CurrentBid,
-		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.Round,
+		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.AutoClose, &a.Round, &a.OrganizationID, &a.RTMTeamID,
+		&a.CreatedAt, &a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetCurrentForUpdate returns the current auction and locks its row until the
+// surrounding transaction ends, serialising bids and sales on the same lot
+func (r *AuctionRepository) GetCurrentForUpdate(ctx context.Context) (*models.Auction, error) {
+	a := &models.Auction{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, season, status, current_player_id, current_bid, current_bidder_id,
+			   timer_duration, timer_remaining, auto_close, round, organization_id, rtm_team_id, created_at, updated_at
+		FROM auctions
+		WHERE id = $1 OR ($1::uuid IS NULL AND status IN ('live', 'paused', 'pending'))
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, r.auction).Scan(
+		&a.ID, &a.Name, &a.Season, &a.Status, &a.CurrentPlayerID, &a.CurrentBid,
+		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.AutoClose, &a.Round, &a.OrganizationID, &a.RTMTeamID,
 		&a.CreatedAt, &a.UpdatedAt,
 	)
 	if err != nil {
@@ -44,11 +71,11 @@ func (r *AuctionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models
 	a := &models.Auction{}
 	err := r.db.QueryRow(ctx, `
 		SELECT id, name, season, status, current_player_id, current_bid, current_bidder_id,
-			   timer_duration, timer_remaining, round, created_at, updated_at
-		FROM auctions WHERE id = $1
-	`, id).Scan(
+			   timer_duration, timer_remaining, auto_close, round, organization_id, rtm_team_id, created_at, updated_at
+		FROM auctions WHERE id = $1 AND `+inOrganization("organization_id", 2)+`
+	`, id, r.org).Scan(
 		&a.ID, &a.Name, &a.Season, &a.Status, &a.CurrentPlayerID, &a.CurrentBid,
-		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.Round,
+		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.AutoClose, &a.Round, &a.OrganizationID, &a.RTMTeamID,
 		&a.CreatedAt, &a.UpdatedAt,
 	)
 	if err != nil {
@@ -57,13 +84,65 @@ func (r *AuctionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models
 	return a, nil
 }
 
-// Create creates a new auction
+// FindDefault returns the auction used when a request does not name one: the latest
+// one not completed, else the latest
+func (r *AuctionRepository) FindDefault(ctx context.Context) (*models.Auction, error) {
+	a := &models.Auction{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, season, status, current_player_id, current_bid, current_bidder_id,
+			   timer_duration, timer_remaining, auto_close, round, organization_id, rtm_team_id, created_at, updated_at
+		FROM auctions
+		WHERE `+inOrganization("organization_id", 1)+`
+		ORDER BY status = 'completed', created_at DESC
+		LIMIT 1
+	`, r.org).Scan(
+		&a.ID, &a.Name, &a.Season, &a.Status, &a.CurrentPlayerID, &a.CurrentBid,
+		&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.AutoClose, &a.Round, &a.OrganizationID, &a.RTMTeamID,
+		&a.CreatedAt, &a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// List returns every auction (of the bound organisation), newest first
+func (r *AuctionRepository) List(ctx context.Context) ([]models.Auction, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, season, status, current_player_id, current_bid, current_bidder_id,
+			   timer_duration, timer_remaining, auto_close, round, organization_id, rtm_team_id, created_at, updated_at
+		FROM auctions
+		WHERE `+inOrganization("organization_id", 1)+`
+		ORDER BY created_at DESC
+	`, r.org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	auctions := []models.Auction{}
+	for rows.Next() {
+		var a models.Auction
+		err := rows.Scan(
+			&a.ID, &a.Name, &a.Season, &a.Status, &a.CurrentPlayerID, &a.CurrentBid,
+			&a.CurrentBidderID, &a.TimerDuration, &a.TimerRemaining, &a.AutoClose, &a.Round, &a.OrganizationID, &a.RTMTeamID,
+			&a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		auctions = append(auctions, a)
+	}
+	return auctions, rows.Err()
+}
+
+// Create creates a new auction in auction.OrganizationID
 func (r *AuctionRepository) Create(ctx context.Context, auction *models.Auction) error {
 	return r.db.QueryRow(ctx, `
-		INSERT INTO auctions (name, season, status, timer_duration, round)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO auctions (name, season, status, timer_duration, auto_close, round, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at, updated_at
-	`, auction.Name, auction.Season, auction.Status, auction.TimerDuration, auction.Round).Scan(
+	`, auction.Name, auction.Season, auction.Status, auction.TimerDuration, auction.AutoClose, auction.Round, auction.OrganizationID).Scan(
 		&auction.ID, &auction.CreatedAt, &auction.UpdatedAt,
 	)
 }
@@ -80,6 +159,16 @@ func (r *AuctionRepository) Update(ctx context.Context, auction *models.Auction)
 	return err
 }
 
+// UpdateDetails updates an auction's name, season, timer duration and auto-close mode
+func (r *AuctionRepository) UpdateDetails(ctx context.Context, auction *models.Auction) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE auctions SET 
+			name = $2, season = $3, timer_duration = $4, auto_close = $5, updated_at = NOW()
+		WHERE id = $1
+	`, auction.ID, auction.Name, auction.Season, auction.TimerDuration, auction.AutoClose)
+	return err
+}
+
 // UpdateCurrentBid updates the current bid info
 func (r *AuctionRepository) UpdateCurrentBid(ctx context.Context, auctionID uuid.UUID, amount int64, bidderID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `
@@ -95,7 +184,7 @@ func (r *AuctionRepository) SetCurrentPlayer(ctx context.Context, auctionID, pla
 	// Note: current_bid is set to NULL initially - first bid will be at base_price
 	_, err := r.db.Exec(ctx, `
 		UPDATE auctions SET 
-			current_player_id = $2, current_bid = NULL, current_bidder_id = NULL, 
+			current_player_id = $2, current_bid = NULL, current_bidder_id = NULL, rtm_team_id = NULL,
 			timer_remaining = timer_duration, updated_at = NOW()
 		WHERE id = $1
 	`, auctionID, playerID)
@@ -110,32 +199,73 @@ func (r *AuctionRepository) UpdateStatus(ctx context.Context, auctionID uuid.UUI
 	return err
 }
 
-// ClearCurrentPlayer clears the current player after sale/unsold
+// UpdateAutoClose sets whether lots close automatically when the timer expires
+func (r *AuctionRepository) UpdateAutoClose(ctx context.Context, auctionID uuid.UUID, autoClose bool) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE auctions SET auto_close = $2, updated_at = NOW() WHERE id = $1
+	`, auctionID, autoClose)
+	return err
+}
+
+// ClearCurrentPlayer clears the current player (and any right-to-match window) after sale/unsold
 func (r *AuctionRepository) ClearCurrentPlayer(ctx context.Context, auctionID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE auctions SET 
-			current_player_id = NULL, current_bid = NULL, current_bidder_id = NULL, updated_at = NOW()
+			current_player_id = NULL, current_bid = NULL, current_bidder_id = NULL, rtm_team_id = NULL, updated_at = NOW()
 		WHERE id = $1
 	`, auctionID)
 	return err
 }
 
-// DeleteCurrent deletes all active/paused auctions (for reset)
-func (r *AuctionRepository) DeleteCurrent(ctx context.Context) error {
-	// First, clear all foreign key references to players to avoid constraint violations
-	_, err := r.db.Exec(ctx, "UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL WHERE status IN ('live', 'paused', 'pending')")
+// SetRTMTeam opens a right-to-match window for a team on the current lot
+func (r *AuctionRepository) SetRTMTeam(ctx context.Context, auctionID, teamID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE auctions SET rtm_team_id = $2, updated_at = NOW() WHERE id = $1
+	`, auctionID, teamID)
+	return err
+}
+
+// Restart puts an auction back to pending with nobody on the block (for reset)
+func (r *AuctionRepository) Restart(ctx context.Context, auctionID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE auctions SET 
+			status = 'pending', current_player_id = NULL, current_bid = NULL, current_bidder_id = NULL,
+			rtm_team_id = NULL, timer_remaining = timer_duration, round = 1, updated_at = NOW()
+		WHERE id = $1
+	`, auctionID)
+	return err
+}
+
+// Delete deletes an auction with its teams, players, bids, sales and settings
+func (r *AuctionRepository) Delete(ctx context.Context, auctionID uuid.UUID) error {
+	// The auction row points at one of the players it is about to take with it
+	_, err := r.db.Exec(ctx, "UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL WHERE id = $1", auctionID)
 	if err != nil {
 		return err
 	}
-	// Now delete the auctions
-	_, err = r.db.Exec(ctx, "DELETE FROM auctions WHERE status IN ('live', 'paused', 'pending')")
+	_, err = r.db.Exec(ctx, "DELETE FROM auctions WHERE id = $1", auctionID)
 	return err
 }
 
-// ClearAllPlayerReferences clears current_player_id from ALL auctions (for complete reset)
-func (r *AuctionRepository) ClearAllPlayerReferences(ctx context.Context) error {
-	_, err := r.db.Exec(ctx, "UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL")
-	return err
+// OverlayToken returns the token that unlocks an auction's broadcast overlays
+func (r *AuctionRepository) OverlayToken(ctx context.Context, id uuid.UUID) (string, error) {
+	var token string
+	err := r.db.QueryRow(ctx, `
+		SELECT overlay_token FROM auctions WHERE id = $1 AND `+inOrganization("organization_id", 2)+`
+	`, id, r.org).Scan(&token)
+	return token, err
+}
+
+// RotateOverlayToken gives an auction a new overlay token, cutting off every overlay URL
+// that carries the old one
+func (r *AuctionRepository) RotateOverlayToken(ctx context.Context, id uuid.UUID) (string, error) {
+	var token string
+	err := r.db.QueryRow(ctx, `
+		UPDATE auctions SET overlay_token = replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', '')
+		WHERE id = $1 AND `+inOrganization("organization_id", 2)+`
+		RETURNING overlay_token
+	`, id, r.org).Scan(&token)
+	return token, err
 }
 
 // DeleteAll deletes ALL auctions (for complete reset)
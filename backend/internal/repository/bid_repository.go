@@ -10,7 +10,8 @@ import (
 
 // BidRepository handles bid database operations
 type BidRepository struct {
-	db *pgxpool.Pool
+	db      Querier
+	auction *uuid.UUID // nil for every auction's bids
 }
 
 // NewBidRepository creates a new bid repository
@@ -21,22 +22,22 @@ func NewBidRepository(db *pgxpool.Pool) *BidRepository {
 // Create creates a new bid
 func (r *BidRepository) Create(ctx context.Context, bid *models.Bid) error {
 	return r.db.QueryRow(ctx, `
-		INSERT INTO bids (auction_id, player_id, team_id, amount)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, bid_time
-	`, bid.AuctionID, bid.PlayerID, bid.TeamID, bid.Amount).Scan(&bid.ID, &bid.BidTime)
+		INSERT INTO bids (auction_id, player_id, team_id, amount, bid_time)
+		VALUES ($1, $2, $3, $4, clock_timestamp())
+		RETURNING id, bid_time, seq
+	`, bid.AuctionID, bid.PlayerID, bid.TeamID, bid.Amount).Scan(&bid.ID, &bid.BidTime, &bid.Seq)
 }
 
 // FindByPlayer returns all bids for a player
 func (r *BidRepository) FindByPlayer(ctx context.Context, playerID uuid.UUID) ([]models.Bid, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT b.id, b.auction_id, b.player_id, b.team_id, b.amount, b.bid_time, b.is_winning,
+		SELECT b.id, b.auction_id, b.player_id, b.team_id, b.amount, b.bid_time, b.seq, b.is_winning, b.voided,
 			   t.name, t.short_name, t.color
 		FROM bids b
 		JOIN teams t ON b.team_id = t.id
-		WHERE b.player_id = $1
-		ORDER BY b.bid_time DESC
-	`, playerID)
+		WHERE b.player_id = $1 AND `+inAuction("b.auction_id", 2)+`
+		ORDER BY b.seq DESC
+	`, playerID, r.auction)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +48,7 @@ func (r *BidRepository) FindByPlayer(ctx context.Context, playerID uuid.UUID) ([
 		var b models.Bid
 		var teamName, teamShort, teamColor string
 		err := rows.Scan(
-			&b.ID, &b.AuctionID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &b.IsWinning,
+			&b.ID, &b.AuctionID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &b.Seq, &b.IsWinning, &b.Voided,
 			&teamName, &teamShort, &teamColor,
 		)
 		if err != nil {
@@ -59,16 +60,16 @@ func (r *BidRepository) FindByPlayer(ctx context.Context, playerID uuid.UUID) ([
 	return bids, nil
 }
 
-// GetLastBid returns the last bid for an auction and player
+// GetLastBid returns the last live (not voided) bid for an auction and player
 func (r *BidRepository) GetLastBid(ctx context.Context, auctionID uuid.UUID, playerID uuid.UUID) (*models.Bid, error) {
 	b := &models.Bid{}
 	err := r.db.QueryRow(ctx, `
-		SELECT id, auction_id, player_id, team_id, amount, bid_time
+		SELECT id, auction_id, player_id, team_id, amount, bid_time, seq
 		FROM bids
-		WHERE auction_id = $1 AND player_id = $2
-		ORDER BY bid_time DESC
+		WHERE auction_id = $1 AND player_id = $2 AND NOT voided
+		ORDER BY seq DESC
 		LIMIT 1
-	`, auctionID, playerID).Scan(&b.ID, &b.AuctionID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime)
+	`, auctionID, playerID).Scan(&b.ID, &b.AuctionID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &b.Seq)
 	if err != nil {
 		return nil, err
 	}
@@ -79,12 +80,21 @@ func (r *BidRepository) GetLastBid(ctx context.Context, auctionID uuid.UUID, pla
 func (r *BidRepository) DeleteLastBid(ctx context.Context, auctionID uuid.UUID, playerID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `
 		DELETE FROM bids WHERE id = (
-			SELECT id FROM bids WHERE auction_id = $1 AND player_id = $2 ORDER BY bid_time DESC LIMIT 1
+			SELECT id FROM bids WHERE auction_id = $1 AND player_id = $2 AND NOT voided ORDER BY seq DESC LIMIT 1
 		)
 	`, auctionID, playerID)
 	return err
 }
 
+// VoidForPlayer voids all live bids for a player after their sale is reversed
+func (r *BidRepository) VoidForPlayer(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE bids SET voided = true, is_winning = false
+		WHERE player_id = $1 AND NOT voided AND `+inAuction("auction_id", 2)+`
+	`, playerID, r.auction)
+	return err
+}
+
 // MarkWinning marks a bid as the winning bid
 func (r *BidRepository) MarkWinning(ctx context.Context, bidID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, "UPDATE bids SET is_winning = true WHERE id = $1", bidID)
@@ -94,12 +104,12 @@ func (r *BidRepository) MarkWinning(ctx context.Context, bidID uuid.UUID) error
 // GetRecentBidsForAuction returns recent bids for an auction
 func (r *BidRepository) GetRecentBidsForAuction(ctx context.Context, auctionID uuid.UUID, limit int) ([]models.Bid, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT b.id, b.player_id, b.team_id, b.amount, b.bid_time,
+		SELECT b.id, b.player_id, b.team_id, b.amount, b.bid_time, b.seq,
 			   t.name, t.short_name, t.color
 		FROM bids b
 		JOIN teams t ON b.team_id = t.id
 		WHERE b.auction_id = $1
-		ORDER BY b.bid_time DESC
+		ORDER BY b.seq DESC
 		LIMIT $2
 	`, auctionID, limit)
 	if err != nil {
@@ -111,7 +121,7 @@ func (r *BidRepository) GetRecentBidsForAuction(ctx context.Context, auctionID u
 	for rows.Next() {
 		var b models.Bid
 		var teamName, teamShort, teamColor string
-		err := rows.Scan(&b.ID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &teamName, &teamShort, &teamColor)
+		err := rows.Scan(&b.ID, &b.PlayerID, &b.TeamID, &b.Amount, &b.BidTime, &b.Seq, &teamName, &teamShort, &teamColor)
 		if err != nil {
 			return nil, err
 		}
@@ -123,13 +133,13 @@ func (r *BidRepository) GetRecentBidsForAuction(ctx context.Context, auctionID u
 
 // DeleteByPlayer deletes all bids for a specific player (used when skipping)
 func (r *BidRepository) DeleteByPlayer(ctx context.Context, playerID uuid.UUID) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM bids WHERE player_id = $1", playerID)
+	_, err := r.db.Exec(ctx, "DELETE FROM bids WHERE player_id = $1 AND "+inAuction("auction_id", 2), playerID, r.auction)
 	return err
 }
 
-// DeleteAll deletes all bids (for reset auction)
+// DeleteAll deletes the bound auction's bids, or every bid when unbound (for reset auction)
 func (r *BidRepository) DeleteAll(ctx context.Context) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM bids")
+	_, err := r.db.Exec(ctx, "DELETE FROM bids WHERE "+inAuction("auction_id", 1), r.auction)
 	return err
 }
 
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrganizationRepository handles organisation database operations
+type OrganizationRepository struct {
+	db Querier
+}
+
+// NewOrganizationRepository creates a new organisation repository
+func NewOrganizationRepository(db *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// FindAll returns every organisation by name
+func (r *OrganizationRepository) FindAll(ctx context.Context) ([]models.Organization, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, slug, created_at, updated_at
+		FROM organizations
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []models.Organization{}
+	for rows.Next() {
+		var o models.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Slug, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, o)
+	}
+	return organizations, rows.Err()
+}
+
+// FindByID finds an organisation by ID
+func (r *OrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	o := &models.Organization{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, slug, created_at, updated_at
+		FROM organizations WHERE id = $1
+	`, id).Scan(&o.ID, &o.Name, &o.Slug, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Create creates a new organisation
+func (r *OrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO organizations (name, slug)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`, org.Name, org.Slug).Scan(&org.ID, &org.CreatedAt, &org.UpdatedAt)
+}
+
+// Update updates an organisation's name and slug
+func (r *OrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE organizations SET name = $2, slug = $3, updated_at = NOW()
+		WHERE id = $1
+	`, org.ID, org.Name, org.Slug)
+	return err
+}
+
+// Delete deletes an organisation with its users and auctions
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM organizations WHERE id = $1", id)
+	return err
+}
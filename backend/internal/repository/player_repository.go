@@ -13,7 +13,8 @@ import (
 
 // PlayerRepository handles player database operations
 type PlayerRepository struct {
-	db *pgxpool.Pool
+	db      Querier
+	auction *uuid.UUID // nil for every auction's players
 }
 
 // NewPlayerRepository creates a new player repository
@@ -27,15 +28,15 @@ func (r *PlayerRepository) FindAll(ctx context.Context, filter models.PlayerFilt
 	query := `
 		SELECT p.id, p.name, p.country, p.country_flag, p.role, p.base_price, p.category,
 			   p.image_url, p.stats, p.status, p.sold_price, p.team_id, p.sold_at,
-			   p.queue_order, p.badge, p.created_at, p.updated_at,
+			   p.queue_order, p.badge, p.previous_team_id, p.uncapped, p.created_at, p.updated_at,
 			   t.id, t.name, t.short_name, t.color
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
-		WHERE 1=1
+		WHERE `+inAuction("p.auction_id", 1)+`
 	`
-	countQuery := "SELECT COUNT(*) FROM players p WHERE 1=1"
-	args := []interface{}{}
-	argCount := 0
+	countQuery := "SELECT COUNT(*) FROM players p WHERE " + inAuction("p.auction_id", 1)
+	args := []interface{}{r.auction}
+	argCount := 1
 
 	if filter.Status != "" {
 		argCount++
@@ -104,7 +105,7 @@ func (r *PlayerRepository) FindAll(ctx context.Context, filter models.PlayerFilt
 		err := rows.Scan(
 			&p.ID, &p.Name, &p.Country, &p.CountryFlag, &p.Role, &p.BasePrice, &p.Category,
 			&p.ImageURL, &statsJSON, &p.Status, &p.SoldPrice, &p.TeamID, &p.SoldAt,
-			&p.QueueOrder, &p.Badge, &p.CreatedAt, &p.UpdatedAt,
+			&p.QueueOrder, &p.Badge, &p.PreviousTeamID, &p.Uncapped, &p.CreatedAt, &p.UpdatedAt,
 			&tID, &teamName, &teamShort, &teamColor,
 		)
 		if err != nil {
@@ -139,15 +140,15 @@ func (r *PlayerRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.
 	err := r.db.QueryRow(ctx, `
 		SELECT p.id, p.name, p.country, p.country_flag, p.role, p.base_price, p.category,
 			   p.image_url, p.stats, p.status, p.sold_price, p.team_id, p.sold_at,
-			   p.queue_order, p.badge, p.created_at, p.updated_at,
+			   p.queue_order, p.badge, p.previous_team_id, p.uncapped, p.created_at, p.updated_at,
 			   t.id, t.name, t.short_name, t.color
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
-		WHERE p.id = $1
-	`, id).Scan(
+		WHERE p.id = $1 AND `+inAuction("p.auction_id", 2)+`
+	`, id, r.auction).Scan(
 		&p.ID, &p.Name, &p.Country, &p.CountryFlag, &p.Role, &p.BasePrice, &p.Category,
 		&p.ImageURL, &statsJSON, &p.Status, &p.SoldPrice, &p.TeamID, &p.SoldAt,
-		&p.QueueOrder, &p.Badge, &p.CreatedAt, &p.UpdatedAt,
+		&p.QueueOrder, &p.Badge, &p.PreviousTeamID, &p.Uncapped, &p.CreatedAt, &p.UpdatedAt,
 		&tID, &teamName, &teamShort, &teamColor,
 	)
 	if err != nil {
@@ -175,7 +176,7 @@ func (r *PlayerRepository) FindByTeamID(ctx context.Context, teamID uuid.UUID) (
 	rows, err := r.db.Query(ctx, `
 		SELECT id, name, country, country_flag, role, base_price, category,
 			   image_url, stats, status, sold_price, team_id, sold_at,
-			   queue_order, badge, created_at, updated_at
+			   queue_order, badge, previous_team_id, uncapped, created_at, updated_at
 		FROM players 
 		WHERE team_id = $1 AND (status = 'sold' OR status = 'retained')
 		ORDER BY CASE WHEN status = 'retained' THEN 0 ELSE 1 END, sold_at DESC
@@ -192,7 +193,7 @@ func (r *PlayerRepository) FindByTeamID(ctx context.Context, teamID uuid.UUID) (
 		err := rows.Scan(
 			&p.ID, &p.Name, &p.Country, &p.CountryFlag, &p.Role, &p.BasePrice, &p.Category,
 			&p.ImageURL, &statsJSON, &p.Status, &p.SoldPrice, &p.TeamID, &p.SoldAt,
-			&p.QueueOrder, &p.Badge, &p.CreatedAt, &p.UpdatedAt,
+			&p.QueueOrder, &p.Badge, &p.PreviousTeamID, &p.Uncapped, &p.CreatedAt, &p.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -215,24 +216,24 @@ func (r *PlayerRepository) GetQueue(ctx context.Context, limit int) ([]models.Pl
 		rows, err = r.db.Query(ctx, `
 			SELECT id, name, country, country_flag, role, base_price, category, queue_order, status
 			FROM players 
-			WHERE status IN ('available', 'unsold')
+			WHERE status IN ('available', 'unsold') AND `+inAuction("auction_id", 2)+`
 			ORDER BY 
 				CASE WHEN status = 'available' THEN 0 ELSE 1 END,
 				COALESCE(queue_order, 999999), 
 				created_at
 			LIMIT $1
-		`, limit)
+		`, limit, r.auction)
 	} else {
 		// No limit - fetch all available and unsold players
 		rows, err = r.db.Query(ctx, `
 			SELECT id, name, country, country_flag, role, base_price, category, queue_order, status
 			FROM players 
-			WHERE status IN ('available', 'unsold')
+			WHERE status IN ('available', 'unsold') AND `+inAuction("auction_id", 1)+`
 			ORDER BY 
 				CASE WHEN status = 'available' THEN 0 ELSE 1 END,
 				COALESCE(queue_order, 999999), 
 				created_at
-		`)
+		`, r.auction)
 	}
 	if err != nil {
 		return nil, err
@@ -255,11 +256,11 @@ func (r *PlayerRepository) GetQueue(ctx context.Context, limit int) ([]models.Pl
 func (r *PlayerRepository) Create(ctx context.Context, player *models.Player) error {
 	statsJSON, _ := json.Marshal(player.Stats)
 	return r.db.QueryRow(ctx, `
-		INSERT INTO players (name, country, country_flag, role, base_price, category, stats, queue_order, image_url)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO players (name, country, country_flag, role, base_price, category, stats, queue_order, image_url, previous_team_id, uncapped, auction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, status, created_at, updated_at
 	`, player.Name, player.Country, player.CountryFlag, player.Role, player.BasePrice,
-		player.Category, statsJSON, player.QueueOrder, player.ImageURL).Scan(
+		player.Category, statsJSON, player.QueueOrder, player.ImageURL, player.PreviousTeamID, player.Uncapped, r.auction).Scan(
 		&player.ID, &player.Status, &player.CreatedAt, &player.UpdatedAt,
 	)
 }
@@ -270,10 +271,10 @@ func (r *PlayerRepository) Update(ctx context.Context, player *models.Player) er
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
 			name = $2, country = $3, country_flag = $4, role = $5, base_price = $6,
-			category = $7, image_url = $8, stats = $9, queue_order = $10, updated_at = NOW()
-		WHERE id = $1
+			category = $7, image_url = $8, stats = $9, queue_order = $10, previous_team_id = $11, uncapped = $12, updated_at = NOW()
+		WHERE id = $1 AND `+inAuction("auction_id", 13)+`
 	`, player.ID, player.Name, player.Country, player.CountryFlag, player.Role, player.BasePrice,
-		player.Category, player.ImageURL, statsJSON, player.QueueOrder)
+		player.Category, player.ImageURL, statsJSON, player.QueueOrder, player.PreviousTeamID, player.Uncapped, r.auction)
 	return err
 }
 
@@ -295,6 +296,16 @@ func (r *PlayerRepository) MarkUnsold(ctx context.Context, playerID uuid.UUID) e
 	return err
 }
 
+// RevertSale clears a player's sale, making them available again
+func (r *PlayerRepository) RevertSale(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE players SET 
+			status = 'available', sold_price = NULL, team_id = NULL, sold_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, playerID)
+	return err
+}
+
 // UpdateStatus updates a player's status
 func (r *PlayerRepository) UpdateStatus(ctx context.Context, playerID uuid.UUID, status string) error {
 	_, err := r.db.Exec(ctx, `
@@ -307,7 +318,7 @@ func (r *PlayerRepository) UpdateStatus(ctx context.Context, playerID uuid.UUID,
 func (r *PlayerRepository) SkipPlayer(ctx context.Context, playerID uuid.UUID) error {
 	// Get the max queue_order and add 1 to put this player at the end
 	var maxOrder int
-	r.db.QueryRow(ctx, "SELECT COALESCE(MAX(queue_order), 0) FROM players WHERE status = 'available'").Scan(&maxOrder)
+	r.db.QueryRow(ctx, "SELECT COALESCE(MAX(queue_order), 0) FROM players WHERE status = 'available' AND "+inAuction("auction_id", 1), r.auction).Scan(&maxOrder)
 	
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
@@ -322,28 +333,74 @@ func (r *PlayerRepository) SkipPlayer(ctx context.Context, playerID uuid.UUID) e
 
 // Delete deletes a player
 func (r *PlayerRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM players WHERE id = $1", id)
+	_, err := r.db.Exec(ctx, "DELETE FROM players WHERE id = $1 AND "+inAuction("auction_id", 2), id, r.auction)
 	return err
 }
 
 // CountByStatus counts players by status
 func (r *PlayerRepository) CountByStatus(ctx context.Context, status string) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM players WHERE status = $1", status).Scan(&count)
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM players WHERE status = $1 AND "+inAuction("auction_id", 2), status, r.auction).Scan(&count)
 	return count, err
 }
 
+// FindIdentityKeys returns every player's lower-cased "name|country" key mapped to its ID (for duplicate detection)
+func (r *PlayerRepository) FindIdentityKeys(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, LOWER(TRIM(name)) || '|' || LOWER(TRIM(country)) FROM players WHERE `+inAuction("auction_id", 1)+`
+	`, r.auction)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[string]uuid.UUID)
+	for rows.Next() {
+		var id uuid.UUID
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, err
+		}
+		keys[key] = id
+	}
+	return keys, rows.Err()
+}
+
+// LockRoster takes a transaction-scoped advisory lock on the auction's players, so
+// imports into the same auction run one at a time. It must be called inside WithTx.
+func (r *PlayerRepository) LockRoster(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended('players:' || COALESCE($1::text, ''), 0))", r.auction)
+	return err
+}
+
+// MaxQueueOrder returns the highest queue order in use (0 if none)
+func (r *PlayerRepository) MaxQueueOrder(ctx context.Context) (int, error) {
+	var maxOrder int
+	err := r.db.QueryRow(ctx, "SELECT COALESCE(MAX(queue_order), 0) FROM players WHERE "+inAuction("auction_id", 1), r.auction).Scan(&maxOrder)
+	return maxOrder, err
+}
+
+// MinBasePrice returns the lowest base price among players still to be auctioned
+func (r *PlayerRepository) MinBasePrice(ctx context.Context) (int64, error) {
+	var price int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(MIN(base_price), 0) FROM players
+		WHERE status IN ('available', 'unsold') AND `+inAuction("auction_id", 1)+`
+	`, r.auction).Scan(&price)
+	return price, err
+}
+
 // Count returns total number of players
 func (r *PlayerRepository) Count(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM players").Scan(&count)
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM players WHERE "+inAuction("auction_id", 1), r.auction).Scan(&count)
 	return count, err
 }
 
 // GetTotalSoldValue returns total value of sold players
 func (r *PlayerRepository) GetTotalSoldValue(ctx context.Context) (int64, error) {
 	var total int64
-	err := r.db.QueryRow(ctx, "SELECT COALESCE(SUM(sold_price), 0) FROM players WHERE status = 'sold'").Scan(&total)
+	err := r.db.QueryRow(ctx, "SELECT COALESCE(SUM(sold_price), 0) FROM players WHERE status = 'sold' AND "+inAuction("auction_id", 1), r.auction).Scan(&total)
 	return total, err
 }
 
@@ -353,10 +410,10 @@ func (r *PlayerRepository) GetTopBuys(ctx context.Context, limit int) ([]models.
 		SELECT p.id, p.name, p.role, p.sold_price, t.name, t.color
 		FROM players p
 		JOIN teams t ON p.team_id = t.id
-		WHERE p.status = 'sold'
+		WHERE p.status = 'sold' AND `+inAuction("p.auction_id", 2)+`
 		ORDER BY p.sold_price DESC
 		LIMIT $1
-	`, limit)
+	`, limit, r.auction)
 	if err != nil {
 		return nil, err
 	}
@@ -382,10 +439,10 @@ func (r *PlayerRepository) GetRecentSales(ctx context.Context, limit int) ([]mod
 		SELECT p.id, p.name, p.role, p.sold_price, p.sold_at, t.name, t.color
 		FROM players p
 		JOIN teams t ON p.team_id = t.id
-		WHERE p.status = 'sold'
+		WHERE p.status = 'sold' AND `+inAuction("p.auction_id", 2)+`
 		ORDER BY p.sold_at DESC
 		LIMIT $1
-	`, limit)
+	`, limit, r.auction)
 	if err != nil {
 		return nil, err
 	}
@@ -405,32 +462,88 @@ func (r *PlayerRepository) GetRecentSales(ctx context.Context, limit int) ([]mod
 	return players, nil
 }
 
+// GetSaleSheet returns every sold player with their team and the number of live bids, in sale order
+func (r *PlayerRepository) GetSaleSheet(ctx context.Context) ([]models.SaleSheetRow, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT p.name, p.country, p.role, COALESCE(p.category, ''), t.name, t.short_name,
+			   p.base_price, COALESCE(p.sold_price, 0), p.sold_at,
+			   (SELECT COUNT(*) FROM bids b WHERE b.player_id = p.id AND NOT b.voided)
+		FROM players p
+		JOIN teams t ON p.team_id = t.id
+		WHERE p.status = 'sold' AND `+inAuction("p.auction_id", 1)+`
+		ORDER BY p.sold_at, p.name
+	`, r.auction)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sales []models.SaleSheetRow
+	for rows.Next() {
+		var s models.SaleSheetRow
+		err := rows.Scan(&s.PlayerName, &s.Country, &s.Role, &s.Category, &s.TeamName, &s.TeamShort,
+			&s.BasePrice, &s.SoldPrice, &s.SoldAt, &s.BidCount)
+		if err != nil {
+			return nil, err
+		}
+		sales = append(sales, s)
+	}
+	return sales, rows.Err()
+}
+
+// FindByStatus returns all players with a status in queue order
+func (r *PlayerRepository) FindByStatus(ctx context.Context, status string) ([]models.Player, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, country, country_flag, role, base_price, category, queue_order, status
+		FROM players
+		WHERE status = $1 AND `+inAuction("auction_id", 2)+`
+		ORDER BY COALESCE(queue_order, 999999), name
+	`, status, r.auction)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []models.Player
+	for rows.Next() {
+		var p models.Player
+		err := rows.Scan(&p.ID, &p.Name, &p.Country, &p.CountryFlag, &p.Role, &p.BasePrice, &p.Category, &p.QueueOrder, &p.Status)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
 // ResetAllPlayers resets all players to 'available' status, clears sold info
 func (r *PlayerRepository) ResetAllPlayers(ctx context.Context) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
 			status = 'available', sold_price = NULL, team_id = NULL, sold_at = NULL, badge = NULL, updated_at = NOW()
-	`)
+		WHERE `+inAuction("auction_id", 1)+`
+	`, r.auction)
 	return err
 }
 
 // DeleteAll deletes all players from the database
 func (r *PlayerRepository) DeleteAll(ctx context.Context) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM players")
+	_, err := r.db.Exec(ctx, "DELETE FROM players WHERE "+inAuction("auction_id", 1), r.auction)
 	return err
 }
 
-// RetainPlayer marks a player as retained by a team with an optional badge
-func (r *PlayerRepository) RetainPlayer(ctx context.Context, playerID, teamID uuid.UUID, badge string) error {
+// RetainPlayer marks a player as retained by a team with an optional badge. The retention
+// cost is kept as the player's price.
+func (r *PlayerRepository) RetainPlayer(ctx context.Context, playerID, teamID uuid.UUID, badge string, cost int64) error {
 	var badgePtr *string
 	if badge != "" {
 		badgePtr = &badge
 	}
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
-			status = 'retained', team_id = $2, badge = $3, updated_at = NOW()
-		WHERE id = $1
-	`, playerID, teamID, badgePtr)
+			status = 'retained', team_id = $2, badge = $3, sold_price = $4, updated_at = NOW()
+		WHERE id = $1 AND `+inAuction("auction_id", 5)+`
+	`, playerID, teamID, badgePtr, cost, r.auction)
 	return err
 }
 
@@ -438,7 +551,7 @@ func (r *PlayerRepository) RetainPlayer(ctx context.Context, playerID, teamID uu
 func (r *PlayerRepository) ReleaseRetainedPlayer(ctx context.Context, playerID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
-			status = 'available', team_id = NULL, badge = NULL, updated_at = NOW()
+			status = 'available', team_id = NULL, badge = NULL, sold_price = NULL, updated_at = NOW()
 		WHERE id = $1 AND status = 'retained'
 	`, playerID)
 	return err
@@ -448,7 +561,7 @@ func (r *PlayerRepository) ReleaseRetainedPlayer(ctx context.Context, playerID u
 func (r *PlayerRepository) ReleaseAllRetainedByTeam(ctx context.Context, teamID uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE players SET 
-			status = 'available', team_id = NULL, badge = NULL, updated_at = NOW()
+			status = 'available', team_id = NULL, badge = NULL, sold_price = NULL, updated_at = NOW()
 		WHERE team_id = $1 AND status = 'retained'
 	`, teamID)
 	return err
@@ -459,7 +572,7 @@ func (r *PlayerRepository) GetRetainedByTeam(ctx context.Context, teamID uuid.UU
 	rows, err := r.db.Query(ctx, `
 		SELECT id, name, country, country_flag, role, base_price, category,
 			   image_url, stats, status, sold_price, team_id, sold_at,
-			   queue_order, badge, created_at, updated_at
+			   queue_order, badge, previous_team_id, uncapped, created_at, updated_at
 		FROM players 
 		WHERE team_id = $1 AND status = 'retained'
 		ORDER BY name
@@ -476,7 +589,7 @@ func (r *PlayerRepository) GetRetainedByTeam(ctx context.Context, teamID uuid.UU
 		err := rows.Scan(
 			&p.ID, &p.Name, &p.Country, &p.CountryFlag, &p.Role, &p.BasePrice, &p.Category,
 			&p.ImageURL, &statsJSON, &p.Status, &p.SoldPrice, &p.TeamID, &p.SoldAt,
-			&p.QueueOrder, &p.Badge, &p.CreatedAt, &p.UpdatedAt,
+			&p.QueueOrder, &p.Badge, &p.PreviousTeamID, &p.Uncapped, &p.CreatedAt, &p.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
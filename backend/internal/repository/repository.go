@@ -1,34 +1,121 @@
 package repository
 
 import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// Repositories holds all repository instances
+// Querier is implemented by both the connection pool and a transaction,
+// so every repository method can run either standalone or inside WithTx
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Repositories holds all repository instances. Repositories bound to an auction with
+// ForAuction only see that auction's teams, players, bids, sales and settings, and those bound to an
+// organisation with ForOrganization only its users, auctions and season archives;
+// unbound ones see all.
 type Repositories struct {
-	Users    *UserRepository
-	Teams    *TeamRepository
-	Players  *PlayerRepository
-	Auctions *AuctionRepository
-	Bids     *BidRepository
-	Settings *SettingsRepository
-	db       *pgxpool.Pool
+	Organizations *OrganizationRepository
+	Users         *UserRepository
+	Teams         *TeamRepository
+	Players       *PlayerRepository
+	Auctions      *AuctionRepository
+	Bids          *BidRepository
+	Sales         *SaleRepository
+	Settings      *SettingsRepository
+	Archives      *ArchiveRepository
+	db            *pgxpool.Pool
+	q             Querier
+	auction       *uuid.UUID
+	org           *uuid.UUID
 }
 
 // NewRepositories creates all repository instances
 func NewRepositories(db *pgxpool.Pool) *Repositories {
+	return newRepositories(db, db, nil, nil)
+}
+
+// newRepositories binds every repository to q and, when set, to one auction and one organisation
+func newRepositories(q Querier, pool *pgxpool.Pool, auction, org *uuid.UUID) *Repositories {
 	return &Repositories{
-		Users:    NewUserRepository(db),
-		Teams:    NewTeamRepository(db),
-		Players:  NewPlayerRepository(db),
-		Auctions: NewAuctionRepository(db),
-		Bids:     NewBidRepository(db),
-		Settings: NewSettingsRepository(db),
-		db:       db,
+		Organizations: &OrganizationRepository{db: q},
+		Users:         &UserRepository{db: q, org: org},
+		Teams:         &TeamRepository{db: q, auction: auction},
+		Players:       &PlayerRepository{db: q, auction: auction},
+		Auctions:      &AuctionRepository{db: q, auction: auction, org: org},
+		Bids:          &BidRepository{db: q, auction: auction},
+		Sales:         &SaleRepository{db: q, auction: auction},
+		Settings:      &SettingsRepository{db: q, auction: auction},
+		Archives:      &ArchiveRepository{db: q, org: org},
+		db:            pool,
+		q:             q,
+		auction:       auction,
+		org:           org,
 	}
 }
 
+// ForAuction returns repositories bound to one auction: its teams, players, bids, sales
+// and settings, with that auction as the current one
+func (r *Repositories) ForAuction(id uuid.UUID) *Repositories {
+	return newRepositories(r.q, r.db, &id, r.org)
+}
+
+// ForOrganization returns repositories bound to one organisation: its users, auctions and archives
+func (r *Repositories) ForOrganization(id uuid.UUID) *Repositories {
+	return newRepositories(r.q, r.db, r.auction, &id)
+}
+
+// AuctionID returns the auction the repositories are bound to, or nil
+func (r *Repositories) AuctionID() *uuid.UUID {
+	return r.auction
+}
+
+// OrganizationID returns the organisation the repositories are bound to, or nil
+func (r *Repositories) OrganizationID() *uuid.UUID {
+	return r.org
+}
+
 // GetDB returns the database pool
 func (r *Repositories) GetDB() *pgxpool.Pool {
 	return r.db
 }
+
+// WithTx runs fn with repositories bound to a single transaction.
+// The transaction commits if fn returns nil and rolls back otherwise.
+func (r *Repositories) WithTx(ctx context.Context, fn func(tx *Repositories) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(newRepositories(tx, r.db, r.auction, r.org)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InTx returns these repositories bound to a transaction the caller manages
+func (r *Repositories) InTx(tx pgx.Tx) *Repositories {
+	return newRepositories(tx, r.db, r.auction, r.org)
+}
+
+// inAuction is a condition limiting column to the bound auction passed as parameter n.
+// An unbound repository passes NULL, which matches every row.
+func inAuction(column string, n int) string {
+	p := "$" + strconv.Itoa(n)
+	return "(" + p + "::uuid IS NULL OR " + column + " = " + p + ")"
+}
+
+// inOrganization is the same condition for the bound organisation
+func inOrganization(column string, n int) string {
+	return inAuction(column, n)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SaleRepository handles sale record database operations
+type SaleRepository struct {
+	db      Querier
+	auction *uuid.UUID // nil for every auction's sales
+}
+
+// NewSaleRepository creates a new sale repository
+func NewSaleRepository(db *pgxpool.Pool) *SaleRepository {
+	return &SaleRepository{db: db}
+}
+
+// Create records a completed sale
+func (r *SaleRepository) Create(ctx context.Context, sale *models.Sale) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO sales (auction_id, player_id, team_id, bid_id, amount, rtm)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, sold_at
+	`, sale.AuctionID, sale.PlayerID, sale.TeamID, sale.BidID, sale.Amount, sale.RTM).Scan(&sale.ID, &sale.SoldAt)
+}
+
+// FindByPlayer returns the most recent sale of a player
+func (r *SaleRepository) FindByPlayer(ctx context.Context, playerID uuid.UUID) (*models.Sale, error) {
+	return r.scanOne(ctx, `
+		SELECT id, auction_id, player_id, team_id, bid_id, amount, rtm, sold_at,
+			   reversed_at, reversed_by, reversal_reason
+		FROM sales
+		WHERE player_id = $1 AND `+inAuction("auction_id", 2)+`
+		ORDER BY sold_at DESC
+		LIMIT 1
+	`, playerID, r.auction)
+}
+
+// FindActiveByPlayerForUpdate returns a player's standing (not reversed) sale and locks it
+func (r *SaleRepository) FindActiveByPlayerForUpdate(ctx context.Context, playerID uuid.UUID) (*models.Sale, error) {
+	return r.scanOne(ctx, `
+		SELECT id, auction_id, player_id, team_id, bid_id, amount, rtm, sold_at,
+			   reversed_at, reversed_by, reversal_reason
+		FROM sales
+		WHERE player_id = $1 AND reversed_at IS NULL AND `+inAuction("auction_id", 2)+`
+		ORDER BY sold_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, playerID, r.auction)
+}
+
+// Reverse marks a sale as reversed, keeping the row for audit
+func (r *SaleRepository) Reverse(ctx context.Context, sale *models.Sale, reversedBy *uuid.UUID, reason *string) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE sales SET reversed_at = NOW(), reversed_by = $2, reversal_reason = $3
+		WHERE id = $1
+		RETURNING reversed_at, reversed_by, reversal_reason
+	`, sale.ID, reversedBy, reason).Scan(&sale.ReversedAt, &sale.ReversedBy, &sale.ReversalReason)
+}
+
+// CountRTMByTeam returns how many right-to-match cards each of the teams has used in
+// sales that still stand
+func (r *SaleRepository) CountRTMByTeam(ctx context.Context, teamIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT team_id, COUNT(*)::int FROM sales
+		WHERE team_id = ANY($1) AND rtm AND reversed_at IS NULL AND `+inAuction("auction_id", 2)+`
+		GROUP BY team_id
+	`, teamIDs, r.auction)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	used := make(map[uuid.UUID]int, len(teamIDs))
+	for rows.Next() {
+		var teamID uuid.UUID
+		var count int
+		if err := rows.Scan(&teamID, &count); err != nil {
+			return nil, err
+		}
+		used[teamID] = count
+	}
+	return used, rows.Err()
+}
+
+func (r *SaleRepository) scanOne(ctx context.Context, query string, args ...interface{}) (*models.Sale, error) {
+	s := &models.Sale{}
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&s.ID, &s.AuctionID, &s.PlayerID, &s.TeamID, &s.BidID, &s.Amount, &s.RTM, &s.SoldAt,
+		&s.ReversedAt, &s.ReversedBy, &s.ReversalReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
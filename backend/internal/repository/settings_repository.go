@@ -4,12 +4,20 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// SettingsRepository handles settings database operations
+// settingsConflict is the unique index settings are upserted on: one value per key for
+// each auction, and one deployment default (auction_id NULL)
+const settingsConflict = `(COALESCE(auction_id, '00000000-0000-0000-0000-000000000000'::uuid), key)`
+
+// SettingsRepository handles settings database operations. Bound to an auction it reads
+// the auction's own values over the defaults and writes the auction's own values;
+// unbound it reads and writes the defaults.
 type SettingsRepository struct {
-	db *pgxpool.Pool
+	db      Querier
+	auction *uuid.UUID
 }
 
 // NewSettingsRepository creates a new settings repository
@@ -19,7 +27,11 @@ func NewSettingsRepository(db *pgxpool.Pool) *SettingsRepository {
 
 // GetAll returns all settings as a map
 func (r *SettingsRepository) GetAll(ctx context.Context) (map[string]interface{}, error) {
-	rows, err := r.db.Query(ctx, "SELECT key, value FROM settings")
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (key) key, value FROM settings
+		WHERE auction_id IS NULL OR auction_id = $1
+		ORDER BY key, auction_id NULLS LAST
+	`, r.auction)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +54,12 @@ func (r *SettingsRepository) GetAll(ctx context.Context) (map[string]interface{}
 // Get returns a single setting value
 func (r *SettingsRepository) Get(ctx context.Context, key string) (interface{}, error) {
 	var valueJSON []byte
-	err := r.db.QueryRow(ctx, "SELECT value FROM settings WHERE key = $1", key).Scan(&valueJSON)
+	err := r.db.QueryRow(ctx, `
+		SELECT value FROM settings
+		WHERE key = $1 AND (auction_id IS NULL OR auction_id = $2)
+		ORDER BY auction_id NULLS LAST
+		LIMIT 1
+	`, key, r.auction).Scan(&valueJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +75,9 @@ func (r *SettingsRepository) Set(ctx context.Context, key string, value interfac
 		return err
 	}
 	_, err = r.db.Exec(ctx, `
-		INSERT INTO settings (key, value, updated_at) VALUES ($1, $2, NOW())
-		ON CONFLICT (key) DO UPDATE SET value = $2, updated_at = NOW()
-	`, key, valueJSON)
+		INSERT INTO settings (key, value, auction_id, updated_at) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT `+settingsConflict+` DO UPDATE SET value = $2, updated_at = NOW()
+	`, key, valueJSON, r.auction)
 	return err
 }
 
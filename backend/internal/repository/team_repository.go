@@ -2,15 +2,42 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/utils"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// foreignCountColumn counts a team's overseas players, i.e. those whose country
+// differs from the team's auction's home_country setting (India when unset). Either
+// side may be stored as a name or a flag emoji; both are compared as utils.SameCountry
+// compares them.
+var foreignCountColumn = `COALESCE(SUM(CASE WHEN p.country IS NOT NULL AND ` + countryKeyCase("p.country") + ` <> (
+				SELECT ` + countryKeyCase("home.name") + ` FROM (SELECT COALESCE(
+					(SELECT value #>> '{}' FROM settings
+					 WHERE key = 'home_country' AND (auction_id = t.auction_id OR auction_id IS NULL)
+					 ORDER BY auction_id NULLS LAST LIMIT 1), 'India'
+				) AS name) home
+			) THEN 1 ELSE 0 END), 0)::int as foreign_count`
+
+// countryKeyCase returns a CASE expression giving the key utils.SameCountry compares:
+// the flag of a known country name, else the lower-cased, trimmed value
+func countryKeyCase(column string) string {
+	var sql strings.Builder
+	sql.WriteString("CASE LOWER(TRIM(" + column + "))")
+	for _, country := range utils.CountryFlags() {
+		sql.WriteString(" WHEN '" + country[0] + "' THEN '" + country[1] + "'")
+	}
+	sql.WriteString(" ELSE LOWER(TRIM(" + column + ")) END")
+	return sql.String()
+}
+
 // TeamRepository handles team database operations
 type TeamRepository struct {
-	db *pgxpool.Pool
+	db      Querier
+	auction *uuid.UUID // nil for every auction's teams
 }
 
 // NewTeamRepository creates a new team repository
@@ -24,12 +51,13 @@ func (r *TeamRepository) FindAll(ctx context.Context) ([]models.Team, error) {
 		SELECT t.id, t.name, t.short_name, t.color, t.logo_url, t.budget, t.spent,
 			   t.max_players, t.max_foreign, t.created_at, t.updated_at,
 			   COALESCE(COUNT(p.id), 0)::int as player_count,
-			   COALESCE(SUM(CASE WHEN p.country NOT IN ('India', '🇮🇳') THEN 1 ELSE 0 END), 0)::int as foreign_count
+			   `+foreignCountColumn+`
 		FROM teams t
 		LEFT JOIN players p ON p.team_id = t.id AND (p.status = 'sold' OR p.status = 'retained')
+		WHERE `+inAuction("t.auction_id", 1)+`
 		GROUP BY t.id
 		ORDER BY t.name
-	`)
+	`, r.auction)
 	if err != nil {
 		return nil, err
 	}
@@ -59,12 +87,12 @@ func (r *TeamRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Te
 		SELECT t.id, t.name, t.short_name, t.color, t.logo_url, t.budget, t.spent,
 			   t.max_players, t.max_foreign, t.created_at, t.updated_at,
 			   COALESCE(COUNT(p.id), 0)::int as player_count,
-			   COALESCE(SUM(CASE WHEN p.country NOT IN ('India', '🇮🇳') THEN 1 ELSE 0 END), 0)::int as foreign_count
+			   `+foreignCountColumn+`
 		FROM teams t
 		LEFT JOIN players p ON p.team_id = t.id AND (p.status = 'sold' OR p.status = 'retained')
-		WHERE t.id = $1
+		WHERE t.id = $1 AND `+inAuction("t.auction_id", 2)+`
 		GROUP BY t.id
-	`, id).Scan(
+	`, id, r.auction).Scan(
 		&t.ID, &t.Name, &t.ShortName, &t.Color, &t.LogoURL, &t.Budget, &t.Spent,
 		&t.MaxPlayers, &t.MaxForeign, &t.CreatedAt, &t.UpdatedAt,
 		&t.PlayerCount, &t.ForeignCount,
@@ -79,10 +107,10 @@ func (r *TeamRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Te
 // Create creates a new team
 func (r *TeamRepository) Create(ctx context.Context, team *models.Team) error {
 	return r.db.QueryRow(ctx, `
-		INSERT INTO teams (name, short_name, color, budget, max_players, max_foreign, logo_url)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO teams (name, short_name, color, budget, max_players, max_foreign, logo_url, auction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, spent, created_at, updated_at
-	`, team.Name, team.ShortName, team.Color, team.Budget, team.MaxPlayers, team.MaxForeign, team.LogoURL).Scan(
+	`, team.Name, team.ShortName, team.Color, team.Budget, team.MaxPlayers, team.MaxForeign, team.LogoURL, r.auction).Scan(
 		&team.ID, &team.Spent, &team.CreatedAt, &team.UpdatedAt,
 	)
 }
@@ -93,9 +121,9 @@ func (r *TeamRepository) Update(ctx context.Context, team *models.Team) error {
 		UPDATE teams SET 
 			name = $2, short_name = $3, color = $4, logo_url = $5, 
 			budget = $6, max_players = $7, max_foreign = $8, updated_at = NOW()
-		WHERE id = $1
+		WHERE id = $1 AND `+inAuction("auction_id", 9)+`
 	`, team.ID, team.Name, team.ShortName, team.Color, team.LogoURL,
-		team.Budget, team.MaxPlayers, team.MaxForeign)
+		team.Budget, team.MaxPlayers, team.MaxForeign, r.auction)
 	return err
 }
 
@@ -109,26 +137,33 @@ func (r *TeamRepository) UpdateSpent(ctx context.Context, id uuid.UUID, amount i
 
 // Delete deletes a team
 func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM teams WHERE id = $1", id)
+	_, err := r.db.Exec(ctx, "DELETE FROM teams WHERE id = $1 AND "+inAuction("auction_id", 2), id, r.auction)
 	return err
 }
 
 // Count returns total number of teams
 func (r *TeamRepository) Count(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM teams").Scan(&count)
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM teams WHERE "+inAuction("auction_id", 1), r.auction).Scan(&count)
 	return count, err
 }
 
 // ResetAllSpent resets spent amount to 0 for all teams
 func (r *TeamRepository) ResetAllSpent(ctx context.Context) error {
-	_, err := r.db.Exec(ctx, "UPDATE teams SET spent = 0, updated_at = NOW()")
+	_, err := r.db.Exec(ctx, "UPDATE teams SET spent = 0, updated_at = NOW() WHERE "+inAuction("auction_id", 1), r.auction)
 	return err
 }
 
 // DeleteAll deletes all teams from the database
 func (r *TeamRepository) DeleteAll(ctx context.Context) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM teams")
+	_, err := r.db.Exec(ctx, "DELETE FROM teams WHERE "+inAuction("auction_id", 1), r.auction)
 	return err
 }
 
+// AuctionOf returns the auction a team belongs to, whichever auction the repository is bound to
+func (r *TeamRepository) AuctionOf(ctx context.Context, teamID uuid.UUID) (uuid.UUID, error) {
+	var auctionID uuid.UUID
+	err := r.db.QueryRow(ctx, "SELECT auction_id FROM teams WHERE id = $1 AND auction_id IS NOT NULL", teamID).Scan(&auctionID)
+	return auctionID, err
+}
+
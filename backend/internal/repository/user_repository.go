@@ -10,7 +10,8 @@ import (
 
 // UserRepository handles user database operations
 type UserRepository struct {
-	db *pgxpool.Pool
+	db  Querier
+	org *uuid.UUID // nil for every organisation's users
 }
 
 // NewUserRepository creates a new user repository
@@ -18,15 +19,15 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// FindByEmail finds a user by email
+// FindByEmail finds a user by email, in any organisation (for signing in)
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := &models.User{}
 	err := r.db.QueryRow(ctx, `
-		SELECT id, email, password_hash, name, role, team_id, status, created_at, updated_at
+		SELECT id, email, password_hash, name, role, team_id, organization_id, status, created_at, updated_at
 		FROM users WHERE email = $1
 	`, email).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Role,
-		&user.TeamID, &user.Status, &user.CreatedAt, &user.UpdatedAt,
+		&user.TeamID, &user.OrganizationID, &user.Status, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -38,11 +39,11 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	user := &models.User{}
 	err := r.db.QueryRow(ctx, `
-		SELECT id, email, password_hash, name, role, team_id, status, created_at, updated_at
-		FROM users WHERE id = $1
-	`, id).Scan(
+		SELECT id, email, password_hash, name, role, team_id, organization_id, status, created_at, updated_at
+		FROM users WHERE id = $1 AND `+inOrganization("organization_id", 2)+`
+	`, id, r.org).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Role,
-		&user.TeamID, &user.Status, &user.CreatedAt, &user.UpdatedAt,
+		&user.TeamID, &user.OrganizationID, &user.Status, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -53,12 +54,13 @@ func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
 // FindAll returns all users with optional team info
 func (r *UserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT u.id, u.email, u.name, u.role, u.team_id, u.status, u.created_at, u.updated_at,
+		SELECT u.id, u.email, u.name, u.role, u.team_id, u.organization_id, u.status, u.created_at, u.updated_at,
 			   t.id, t.name, t.short_name, t.color
 		FROM users u
 		LEFT JOIN teams t ON u.team_id = t.id
+		WHERE `+inOrganization("u.organization_id", 1)+`
 		ORDER BY u.created_at DESC
-	`)
+	`, r.org)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +73,7 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 		var tID *uuid.UUID
 		
 		err := rows.Scan(
-			&u.ID, &u.Email, &u.Name, &u.Role, &u.TeamID, &u.Status, &u.CreatedAt, &u.UpdatedAt,
+			&u.ID, &u.Email, &u.Name, &u.Role, &u.TeamID, &u.OrganizationID, &u.Status, &u.CreatedAt, &u.UpdatedAt,
 			&tID, &teamName, &teamShort, &teamColor,
 		)
 		if err != nil {
@@ -92,13 +94,13 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	return users, nil
 }
 
-// Create creates a new user
+// Create creates a new user in user.OrganizationID
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	return r.db.QueryRow(ctx, `
-		INSERT INTO users (email, password_hash, name, role, team_id, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (email, password_hash, name, role, team_id, status, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at, updated_at
-	`, user.Email, user.PasswordHash, user.Name, user.Role, user.TeamID, user.Status).Scan(
+	`, user.Email, user.PasswordHash, user.Name, user.Role, user.TeamID, user.Status, user.OrganizationID).Scan(
 		&user.ID, &user.CreatedAt, &user.UpdatedAt,
 	)
 }
@@ -108,8 +110,8 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE users SET 
 			email = $2, name = $3, role = $4, team_id = $5, status = $6, updated_at = NOW()
-		WHERE id = $1
-	`, user.ID, user.Email, user.Name, user.Role, user.TeamID, user.Status)
+		WHERE id = $1 AND `+inOrganization("organization_id", 7)+`
+	`, user.ID, user.Email, user.Name, user.Role, user.TeamID, user.Status, r.org)
 	return err
 }
 
@@ -124,13 +126,13 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passw
 
 // Delete deletes a user
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+	_, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1 AND "+inOrganization("organization_id", 2), id, r.org)
 	return err
 }
 
 // CountByRole counts users by role
 func (r *UserRepository) CountByRole(ctx context.Context, role string) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE role = $1", role).Scan(&count)
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE role = $1 AND "+inOrganization("organization_id", 2), role, r.org).Scan(&count)
 	return count, err
 }
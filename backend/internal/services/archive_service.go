@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrArchiveNotFound is returned for an archive ID that does not exist
+var ErrArchiveNotFound = errors.New("archive not found")
+
+// Archive reasons: asked for, or taken automatically before a reset
+const (
+	archiveManual          = "manual"
+	archiveReset           = "reset"
+	archiveResetEverything = "reset_everything"
+)
+
+// ArchiveService keeps past seasons: immutable snapshots of an auction's settings,
+// purses, squads, sales and bids. One bound to an organisation only sees its archives.
+type ArchiveService struct {
+	repos *repository.Repositories
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(repos *repository.Repositories) *ArchiveService {
+	return &ArchiveService{repos: repos}
+}
+
+// Archive snapshots the bound auction as it stands. The auction must not be live or
+// have a player on the block, so the archive holds only finished lots.
+func (s *ArchiveService) Archive(ctx context.Context, archivedBy *uuid.UUID) (*models.SeasonArchive, error) {
+	if s.repos.AuctionID() == nil {
+		return nil, ErrAuctionNotFound
+	}
+
+	var archive *models.SeasonArchive
+	err := s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		// Lock the auction so no sale lands halfway through the snapshot
+		auction, err := tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return ErrAuctionNotFound
+		}
+		if auction.Status == "live" {
+			return errors.New("pause or end the auction before archiving it")
+		}
+		if auction.CurrentPlayerID != nil {
+			return errors.New("close the current lot before archiving the auction")
+		}
+		archive, err = tx.Archives.Snapshot(ctx, auction.ID, archiveManual, archivedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// archiveBeforeReset archives every auction a reset is about to clear (the bound one,
+// or all of them) that has results not archived yet. tx is the reset's transaction.
+func archiveBeforeReset(ctx context.Context, tx *repository.Repositories, reason string) error {
+	var ids []uuid.UUID
+	if id := tx.AuctionID(); id != nil {
+		ids = append(ids, *id)
+	} else {
+		auctions, err := tx.Auctions.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, auction := range auctions {
+			ids = append(ids, auction.ID)
+		}
+	}
+
+	for _, id := range ids {
+		pending, err := tx.Archives.HasUnarchivedActivity(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !pending {
+			continue
+		}
+		if _, err := tx.Archives.Snapshot(ctx, id, reason, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns past seasons newest first, only one auction's when auctionID is set
+func (s *ArchiveService) List(ctx context.Context, auctionID *uuid.UUID) ([]models.SeasonArchive, error) {
+	return s.repos.Archives.List(ctx, auctionID)
+}
+
+// Get returns a past season: every team's purse and squad, the players left unsold (or
+// never on the block) and the sales, reversed ones included
+func (s *ArchiveService) Get(ctx context.Context, id uuid.UUID) (*models.SeasonArchiveDetail, error) {
+	archive, err := s.repos.Archives.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrArchiveNotFound
+	}
+	teams, err := s.repos.Archives.FindTeams(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	players, err := s.repos.Archives.FindPlayers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.repos.Archives.FindSales(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.SeasonArchiveDetail{
+		SeasonArchive: *archive,
+		Teams:         teams,
+		Unsold:        []models.ArchivedPlayer{},
+		Sales:         sales,
+	}
+	squads := make(map[uuid.UUID]int, len(teams))
+	for i, team := range teams {
+		squads[team.TeamID] = i
+	}
+	for _, player := range players {
+		if player.Status == "sold" || player.Status == "retained" {
+			if player.TeamID != nil {
+				if i, ok := squads[*player.TeamID]; ok {
+					detail.Teams[i].Squad = append(detail.Teams[i].Squad, player)
+				}
+			}
+			continue
+		}
+		detail.Unsold = append(detail.Unsold, player)
+	}
+	return detail, nil
+}
+
+// GetBids returns a past season's bids in the order they were accepted, only one
+// player's when playerID is set
+func (s *ArchiveService) GetBids(ctx context.Context, id uuid.UUID, playerID *uuid.UUID) ([]models.ArchivedBid, error) {
+	if _, err := s.repos.Archives.FindByID(ctx, id); err != nil {
+		return nil, ErrArchiveNotFound
+	}
+	return s.repos.Archives.FindBids(ctx, id, playerID)
+}
+
+// PlayerHistory returns the seasons of the player with this name and country, with
+// what each went for and to whom. Players are matched case-insensitively, since every
+// season (and every auction) registers its own player rows.
+func (s *ArchiveService) PlayerHistory(ctx context.Context, name, country string) (*models.PlayerHistory, error) {
+	name, country = strings.TrimSpace(name), strings.TrimSpace(country)
+	if name == "" || country == "" {
+		return nil, errors.New("name and country are required")
+	}
+	seasons, err := s.repos.Archives.FindPlayerSeasons(ctx, name, country)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PlayerHistory{Name: name, Country: country, Seasons: seasons}, nil
+}
@@ -3,7 +3,8 @@ package services
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,17 +16,63 @@ import (
 
 // AuctionService handles auction operations
 type AuctionService struct {
-	repos       *repository.Repositories
-	redis       *redis.Client
-	timerLock   sync.Mutex
-	timerCancel context.CancelFunc
+	repos      *repository.Repositories
+	redis      *redis.Client
+	instanceID string // identifies this process when holding the timer lease
+	timerLock  sync.Mutex
+	stateCache stateCache // materialised GetState, see state_cache.go
+
+	// Lot timer callbacks, registered by the handlers layer which owns broadcasting
+	onTimerTick    func(remaining int)
+	onTimerExpired func()
+}
+
+// ErrAuctionCompleted is returned when a completed auction would be started, paused or
+// resumed; only a reset brings it back
+var ErrAuctionCompleted = errors.New("the auction has ended; reset it to run it again")
+
+// Bids from regular bidders are spaced at least bidFreezeWindow apart
+const (
+	bidFreezeKey    = "bid_freeze"
+	bidFreezeWindow = 1 * time.Second
+)
+
+// bidderBiddingDisabledKey is set while the host has stopped bidders bidding themselves
+const bidderBiddingDisabledKey = "bidder_bidding_disabled"
+
+// LotCloseResult describes what happened to the player on the block when the timer ran out
+type LotCloseResult struct {
+	Closed bool           // false when the auction waits for the host's hammer
+	Sold   bool           // true if sold, false if marked unsold
+	Player *models.Player
+	Team   *models.Team
+	RTM    *models.RTMOffer // set instead of Sold when the sale waits on a right to match
+	Lapsed *RTMResult       // set when the timer ended a right-to-match window
 }
 
 // NewAuctionService creates a new auction service
 func NewAuctionService(repos *repository.Repositories, redis *redis.Client) *AuctionService {
 	return &AuctionService{
-		repos: repos,
-		redis: redis,
+		repos:      repos,
+		redis:      redis,
+		instanceID: uuid.NewString(),
+	}
+}
+
+// key names one of the auction's Redis keys: auction:<id>:<name> for a service bound to
+// an auction, auction:<name> for the unbound one
+func (s *AuctionService) key(name string) string {
+	if id := s.repos.AuctionID(); id != nil {
+		return "auction:" + id.String() + ":" + name
+	}
+	return "auction:" + name
+}
+
+// liveKeys are the Redis keys holding the auction's countdown, bid freeze and host switches
+func (s *AuctionService) liveKeys() []string {
+	return []string{
+		s.key(timerRemainingKey), s.key(timerRunningKey), s.key(timerDeadlineKey),
+		s.key(bidFreezeKey), s.key(bidderBiddingDisabledKey),
 	}
 }
 
@@ -52,64 +99,26 @@ func (s *AuctionService) GetState(ctx context.Context) (*models.AuctionState, er
 		}, nil
 	}
 
-	state := &models.AuctionState{
-		Auction: auction,
-		Status:  auction.Status,
-	}
-
-	// Get current player if any
-	if auction.CurrentPlayerID != nil {
-		player, err := s.repos.Players.FindByID(queryCtx, *auction.CurrentPlayerID)
-		if err == nil {
-			state.CurrentPlayer = player
-			
-			// Set current_bid for frontend: use actual bid if exists, otherwise base_price
-			if auction.CurrentBid != nil {
-				state.CurrentBid = auction.CurrentBid
-			} else {
-				state.CurrentBid = &player.BasePrice
-			}
-		}
-	}
+	ladder := s.BidLadder(queryCtx)
+	maxBid := ladder.Max()
 
-	// Get current bidder if any
-	if auction.CurrentBidderID != nil {
-		team, err := s.repos.Teams.FindByID(queryCtx, *auction.CurrentBidderID)
-		if err == nil {
-			state.CurrentBidder = team
-		}
-	}
-
-	// Get bid history for current player (only this player's bids)
-	if auction.CurrentPlayerID != nil {
-		bids, err := s.repos.Bids.FindByPlayer(queryCtx, *auction.CurrentPlayerID)
-		if err == nil {
-			state.BidHistory = bids
-			
-			// Check for tied teams at max bid (50000)
-			if auction.CurrentBid != nil && *auction.CurrentBid == maxBid {
-				// Find all unique teams that bid 50000
-				teamIDs := make(map[uuid.UUID]bool)
-				for _, bid := range bids {
-					if bid.Amount == maxBid {
-						teamIDs[bid.TeamID] = true
-					}
-				}
-				// If more than 1 team bid 50000, it's a tie
-				if len(teamIDs) > 1 {
-					for teamID := range teamIDs {
-						team, err := s.repos.Teams.FindByID(queryCtx, teamID)
-						if err == nil && team != nil {
-							state.TiedTeams = append(state.TiedTeams, *team)
-						}
-					}
-				}
-			}
-		}
-	}
-
-	// Get all teams
-	teams, err := s.repos.Teams.FindAll(queryCtx)
+	state := &models.AuctionState{
+		Auction:   auction,
+		Status:    auction.Status,
+		BidLadder: ladder,
+		MaxBid:    maxBid,
+	}
+
+	lot := s.loadLot(queryCtx, auction, maxBid)
+	state.CurrentPlayer = lot.CurrentPlayer
+	state.CurrentBid = lot.CurrentBid
+	state.CurrentBidder = lot.CurrentBidder
+	state.BidHistory = lot.BidHistory
+	state.TiedTeams = lot.TiedTeams
+	state.RTM = lot.RTM
+
+	// Get all teams with their live bidding headroom
+	teams, err := s.teamsWithHeadroom(queryCtx, ladder)
 	if err == nil {
 		state.Teams = teams
 	}
@@ -120,46 +129,39 @@ func (s *AuctionService) GetState(ctx context.Context) (*models.AuctionState, er
 		state.QueueNext = queue
 	}
 
-	// Check if timer is running (from Redis)
-	timerRunning, _ := s.redis.Get(ctx, "auction:timer_running").Bool()
-	state.TimerRunning = timerRunning
-
-	// Check if bidding is frozen (1 second after last bid)
-	freezeKey := "auction:bid_freeze"
-	freeze, _ := s.redis.Get(ctx, freezeKey).Int64()
-	now := time.Now().UnixMilli()
-	state.BidFrozen = freeze > 0 && now-freeze < 1000
+	// Timer state is derived from the deadline stored in Redis
+	timer := s.TimerStatus(ctx)
+	state.TimerRunning = timer.Running
+	state.TimerRemaining = timer.Remaining
+	state.TimerDeadline = timer.Deadline
 
-	// Check if bidder bidding is disabled
-	bidderBiddingDisabled, _ := s.redis.Get(ctx, "auction:bidder_bidding_disabled").Bool()
-	state.BidderBiddingDisabled = bidderBiddingDisabled
+	state.BidFrozen = s.bidFrozen(ctx)
+	state.BidderBiddingDisabled = s.bidderBiddingDisabled(ctx)
 
 	return state, nil
 }
 
 // StartAuction starts or resumes an auction
 func (s *AuctionService) StartAuction(ctx context.Context) (*models.Auction, error) {
+	defer s.InvalidateState(ctx)
+
 	// Check if there's an existing auction
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
+		// An auction created through the auctions API is never recreated here
+		if s.repos.AuctionID() != nil {
+			return nil, ErrAuctionNotFound
+		}
 		// Create new auction
 		settings, _ := s.repos.Settings.GetAll(ctx)
-		timerDuration := 30
-		if td, ok := settings["timer_duration"].(float64); ok {
-			timerDuration = int(td)
-		}
-
-		auction = &models.Auction{
-			Name:          "Auction Session",
-			Season:        "2026",
-			Status:        "live",
-			TimerDuration: timerDuration,
-			Round:         1,
-		}
+		auction = newAuction(settings, "live")
 		if err := s.repos.Auctions.Create(ctx, auction); err != nil {
 			return nil, err
 		}
 	} else {
+		if auction.Status == "completed" {
+			return nil, ErrAuctionCompleted
+		}
 		// Resume existing auction
 		auction.Status = "live"
 		if err := s.repos.Auctions.UpdateStatus(ctx, auction.ID, "live"); err != nil {
@@ -172,10 +174,15 @@ func (s *AuctionService) StartAuction(ctx context.Context) (*models.Auction, err
 
 // PauseAuction pauses the auction
 func (s *AuctionService) PauseAuction(ctx context.Context) error {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return errors.New("no active auction")
 	}
+	if auction.Status == "completed" {
+		return ErrAuctionCompleted
+	}
 
 	s.StopTimer()
 	return s.repos.Auctions.UpdateStatus(ctx, auction.ID, "paused")
@@ -183,16 +190,31 @@ func (s *AuctionService) PauseAuction(ctx context.Context) error {
 
 // ResumeAuction resumes a paused auction
 func (s *AuctionService) ResumeAuction(ctx context.Context) error {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return errors.New("no active auction")
 	}
+	if auction.Status == "completed" {
+		return ErrAuctionCompleted
+	}
 
-	return s.repos.Auctions.UpdateStatus(ctx, auction.ID, "live")
+	if err := s.repos.Auctions.UpdateStatus(ctx, auction.ID, "live"); err != nil {
+		return err
+	}
+
+	// Continue the countdown from where it was paused
+	if auction.CurrentPlayerID != nil {
+		s.resumeLotTimer(ctx)
+	}
+	return nil
 }
 
 // EndAuction ends the current auction
 func (s *AuctionService) EndAuction(ctx context.Context) error {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return errors.New("no active auction")
@@ -204,6 +226,8 @@ func (s *AuctionService) EndAuction(ctx context.Context) error {
 
 // NextPlayer moves to the next player in queue
 func (s *AuctionService) NextPlayer(ctx context.Context) (*models.Player, error) {
+	defer s.InvalidateState(ctx)
+
 	// Add timeout for critical operation
 	queryCtx, cancel := s.withTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -230,9 +254,8 @@ func (s *AuctionService) NextPlayer(ctx context.Context) (*models.Player, error)
 		return nil, err
 	}
 
-	// Reset timer in Redis
-	s.redis.Set(ctx, "auction:timer_remaining", auction.TimerDuration, 0)
-	s.redis.Set(ctx, "auction:timer_running", false, 0)
+	// Start the countdown for the new lot
+	s.restartLotTimer(ctx, auction)
 
 	return fullPlayer, nil
 }
@@ -240,6 +263,8 @@ func (s *AuctionService) NextPlayer(ctx context.Context) (*models.Player, error)
 // StartBidForPlayer starts bidding for a specific player (manual selection by host)
 // Allows selecting both available and unsold players (unsold players are made available again)
 func (s *AuctionService) StartBidForPlayer(ctx context.Context, playerID uuid.UUID) (*models.Player, error) {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return nil, errors.New("no active auction")
@@ -269,257 +294,465 @@ func (s *AuctionService) StartBidForPlayer(ctx context.Context, playerID uuid.UU
 		return nil, err
 	}
 
-	// Reset timer in Redis
-	s.redis.Set(ctx, "auction:timer_remaining", auction.TimerDuration, 0)
-	s.redis.Set(ctx, "auction:timer_running", false, 0)
+	// Start the countdown for the new lot
+	s.restartLotTimer(ctx, auction)
 
 	return player, nil
 }
 
-// Bid ladder - the allowed bid amounts in order
-var bidLadder = []int64{
-	2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000,
-	12000, 14000, 16000, 18000, 20000,
-	24000, 28000, 32000, 36000, 40000, 45000, 50000,
-}
-
-const maxBid int64 = 50000
-
-// getNextBidAmount returns the next valid bid amount given the current bid
-func getNextBidAmount(currentBid int64) int64 {
-	if currentBid == 0 {
-		return bidLadder[0] // First bid is 2000
-	}
-	// Find the next bid in the ladder
-	for _, amount := range bidLadder {
-		if amount > currentBid {
-			return amount
-		}
-	}
-	// If current bid is at or above max, return max (for tie-bids at 50000)
-	return maxBid
-}
-
-// isValidBidAmount checks if the amount is in the bid ladder
-func isValidBidAmount(amount int64) bool {
-	for _, validAmount := range bidLadder {
-		if amount == validAmount {
-			return true
-		}
-	}
-	return false
-}
-
 // PlaceBid places a bid on the current player
 // skipBidderCheck: if true, skips the bidder bidding disabled check (for host/admin placing bids)
 // skipFreezeCheck: if true, skips the freeze time check (for host/admin placing bids)
+//
+// Validation and acceptance run in one transaction holding a lock on the auction
+// row, so concurrent bids are applied one at a time against the latest current bid
+// and bids.seq records the order in which they were accepted.
 func (s *AuctionService) PlaceBid(ctx context.Context, teamID uuid.UUID, amount int64, skipBidderCheck bool, skipFreezeCheck bool) (*models.Bid, error) {
 	// Add timeout for critical bid operation (10 seconds for write operations)
 	queryCtx, cancel := s.withTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	auction, err := s.repos.Auctions.GetCurrent(queryCtx)
-	if err != nil || auction.Status != "live" {
-		return nil, errors.New("auction is not active")
-	}
-
-	if auction.CurrentPlayerID == nil {
-		return nil, errors.New("no player currently on block")
-	}
-
-	// Check freeze time - prevent bids within 1 second of the last bid (only for regular bidders)
-	freezeKey := "auction:bid_freeze"
-	if !skipFreezeCheck {
-		freeze, _ := s.redis.Get(ctx, freezeKey).Int64()
-		now := time.Now().UnixMilli()
-		if freeze > 0 && now-freeze < 1000 {
-			return nil, errors.New("please wait - bid in progress")
-		}
-	}
-
 	// Check if bidder bidding is disabled (only for regular bidders, not for host/admin)
 	if !skipBidderCheck {
-		bidderBiddingDisabled, _ := s.redis.Get(ctx, "auction:bidder_bidding_disabled").Bool()
+		bidderBiddingDisabled, _ := s.redis.Get(ctx, s.key(bidderBiddingDisabledKey)).Bool()
 		if bidderBiddingDisabled {
 			return nil, errors.New("bidder bidding is currently disabled by host")
 		}
 	}
 
-	// Prevent same team from bidding consecutively - must wait for another team to bid
-	if auction.CurrentBidderID != nil && *auction.CurrentBidderID == teamID {
-		return nil, errors.New("you are already the leading bidder - wait for another team to bid")
-	}
-
 	// Validate bid is in the ladder
-	if !isValidBidAmount(amount) {
+	ladder := s.BidLadder(queryCtx)
+	maxBid := ladder.Max()
+	if !ladder.Contains(amount) {
 		return nil, errors.New("invalid bid amount - must be from the bid ladder")
 	}
+	// Settings are read before the auction lock is taken: a transaction waiting for a
+	// second pool connection while bidders queue on the lock would stall the auction
+	minSquad := s.minSquadSize(queryCtx)
+	minPrice := s.minPlayerPrice(queryCtx, ladder)
+	home := s.homeCountry(queryCtx)
+
+	var bid *models.Bid
+	var auction *models.Auction
+	var frozen bool // this bid claimed the freeze window
+	err := s.repos.WithTx(queryCtx, func(tx *repository.Repositories) error {
+		var err error
+		auction, err = tx.Auctions.GetCurrentForUpdate(queryCtx)
+		if err != nil || auction.Status != "live" {
+			return errors.New("auction is not active")
+		}
 
-	// Get team to validate budget
-	team, err := s.repos.Teams.FindByID(queryCtx, teamID)
-	if err != nil {
-		return nil, errors.New("team not found")
-	}
+		if auction.CurrentPlayerID == nil {
+			return errors.New("no player currently on block")
+		}
+		if auction.RTMTeamID != nil {
+			return errors.New("bidding has closed - the former team is deciding on its right to match")
+		}
 
-	remainingBudget := team.Budget - team.Spent
-	if amount > remainingBudget {
-		return nil, errors.New("insufficient budget")
-	}
+		// Prevent same team from bidding consecutively - must wait for another team to bid
+		if auction.CurrentBidderID != nil && *auction.CurrentBidderID == teamID {
+			return errors.New("you are already the leading bidder - wait for another team to bid")
+		}
 
-	// Validate bid is higher than current (except at max bid where ties are allowed)
-	currentBid := auction.CurrentBid
-	if currentBid != nil {
-		if amount < *currentBid {
-			return nil, errors.New("bid must be higher than current bid")
+		// Get team to validate budget
+		team, err := tx.Teams.FindByID(queryCtx, teamID)
+		if err != nil {
+			return errors.New("team not found")
 		}
-		// Only allow same-amount bids at max (50000)
-		if amount == *currentBid && amount != maxBid {
-			return nil, errors.New("bid must be higher than current bid")
+
+		remainingBudget := team.Budget - team.Spent
+		if amount > remainingBudget {
+			return errors.New("insufficient budget")
 		}
-	} else {
-		// First bid: must be at least base price
-		player, err := s.repos.Players.FindByID(queryCtx, *auction.CurrentPlayerID)
+
+		player, err := tx.Players.FindByID(queryCtx, *auction.CurrentPlayerID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if amount < player.BasePrice {
-			return nil, errors.New("bid must be at least the base price")
+
+		// Reject bids from teams that could not take the player on
+		if err := checkSquadLimits(team, player, home); err != nil {
+			return err
 		}
-	}
 
-	// Set freeze time BEFORE creating bid to prevent race conditions (only for regular bidders)
-	if !skipFreezeCheck {
-		s.redis.Set(ctx, freezeKey, time.Now().UnixMilli(), 2*time.Second)
-	}
+		// Keep enough purse back to complete the minimum squad
+		team.MaxBid = maxAllowedBid(team, minSquad, minPrice)
+		if amount > team.MaxBid {
+			return fmt.Errorf("bid exceeds your max bid of %d - purse must be reserved to complete the minimum squad", team.MaxBid)
+		}
 
-	// Create bid
-	bid := &models.Bid{
-		AuctionID: auction.ID,
-		PlayerID:  *auction.CurrentPlayerID,
-		TeamID:    teamID,
-		Amount:    amount,
-	}
-	if err := s.repos.Bids.Create(queryCtx, bid); err != nil {
-		return nil, err
-	}
+		// Validate bid is higher than current (except at max bid where ties are allowed)
+		currentBid := auction.CurrentBid
+		if currentBid != nil {
+			if amount < *currentBid {
+				return errors.New("bid must be higher than current bid")
+			}
+			// Only allow same-amount bids at the top of the ladder
+			if amount == *currentBid && amount != maxBid {
+				return errors.New("bid must be higher than current bid")
+			}
+		} else {
+			// First bid: must be at least base price
+			if amount < player.BasePrice {
+				return errors.New("bid must be at least the base price")
+			}
+		}
+
+		// Claim the 1 second freeze window atomically (only for regular bidders)
+		if !skipFreezeCheck {
+			claimed, err := s.redis.SetNX(ctx, s.key(bidFreezeKey), time.Now().UnixMilli(), bidFreezeWindow).Result()
+			if err == nil && !claimed {
+				return errors.New("please wait - bid in progress")
+			}
+			frozen = claimed
+		}
+
+		// Create bid
+		bid = &models.Bid{
+			AuctionID: auction.ID,
+			PlayerID:  *auction.CurrentPlayerID,
+			TeamID:    teamID,
+			Amount:    amount,
+		}
+		if err := tx.Bids.Create(queryCtx, bid); err != nil {
+			return err
+		}
 
-	// Update auction current bid
-	if err := s.repos.Auctions.UpdateCurrentBid(queryCtx, auction.ID, amount, teamID); err != nil {
+		// Update auction current bid
+		if err := tx.Auctions.UpdateCurrentBid(queryCtx, auction.ID, amount, teamID); err != nil {
+			return err
+		}
+
+		// Add team info to bid
+		bid.Team = team
+		return nil
+	})
+	if err != nil {
+		// A bid that was not accepted must not hold up the next one
+		if frozen {
+			s.redis.Del(ctx, s.key(bidFreezeKey))
+		}
 		return nil, err
 	}
 
-	// Reset timer
-	s.redis.Set(ctx, "auction:timer_remaining", auction.TimerDuration, 0)
-
-	// Add team info to bid
-	bid.Team = team
+	// Restart the countdown on every accepted bid
+	s.restartLotTimer(ctx, auction)
+	s.InvalidateState(ctx)
 
 	return bid, nil
 }
 
-// SellPlayer marks the current player as sold
-func (s *AuctionService) SellPlayer(ctx context.Context) (*models.Player, *models.Team, error) {
-	auction, err := s.repos.Auctions.GetCurrent(ctx)
-	if err != nil {
-		return nil, nil, errors.New("no active auction")
-	}
+// SaleResult is the outcome of the hammer: the player and the team they were sold to, or,
+// when the player's previous team may match the winning bid, the right-to-match offer
+// holding the sale until the former team answers
+type SaleResult struct {
+	Player *models.Player   `json:"player,omitempty"`
+	Team   *models.Team     `json:"team,omitempty"`
+	RTM    *models.RTMOffer `json:"rtm,omitempty"`
+}
 
-	if auction.CurrentPlayerID == nil || auction.CurrentBidderID == nil || auction.CurrentBid == nil {
-		return nil, nil, errors.New("no valid bid to sell")
-	}
+// SellPlayer marks the current player as sold to the leading bidder
+func (s *AuctionService) SellPlayer(ctx context.Context) (*SaleResult, error) {
+	defer s.InvalidateState(ctx)
 
-	s.StopTimer()
+	home := s.homeCountry(ctx)
 
-	// Mark player as sold
-	if err := s.repos.Players.MarkSold(ctx, *auction.CurrentPlayerID, *auction.CurrentBidderID, *auction.CurrentBid); err != nil {
-		return nil, nil, err
-	}
+	var sale *models.Sale
+	var offer *models.RTMOffer
+	var auction *models.Auction
+	err := s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		var err error
+		auction, err = tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return errors.New("no active auction")
+		}
 
-	// Update team spent
-	if err := s.repos.Teams.UpdateSpent(ctx, *auction.CurrentBidderID, *auction.CurrentBid); err != nil {
-		return nil, nil, err
-	}
+		if auction.CurrentPlayerID == nil || auction.CurrentBidderID == nil || auction.CurrentBid == nil {
+			return errors.New("no valid bid to sell")
+		}
+		if auction.RTMTeamID != nil {
+			return errors.New("waiting for the former team to use or decline its right to match")
+		}
 
-	// Get sold player and team info
-	player, _ := s.repos.Players.FindByID(ctx, *auction.CurrentPlayerID)
-	team, _ := s.repos.Teams.FindByID(ctx, *auction.CurrentBidderID)
+		// Re-check squad limits - retentions or other sales may have filled the squad since the bid
+		player, err := tx.Players.FindByID(ctx, *auction.CurrentPlayerID)
+		if err != nil {
+			return errors.New("player not found")
+		}
+		team, err := tx.Teams.FindByID(ctx, *auction.CurrentBidderID)
+		if err != nil {
+			return errors.New("team not found")
+		}
+		if err := checkSquadLimits(team, player, home); err != nil {
+			return err
+		}
 
-	// Clear current player from auction
-	if err := s.repos.Auctions.ClearCurrentPlayer(ctx, auction.ID); err != nil {
-		return nil, nil, err
+		// The winning bid is the last one accepted for this lot
+		winning, err := tx.Bids.GetLastBid(ctx, auction.ID, *auction.CurrentPlayerID)
+		if err != nil || winning.TeamID != *auction.CurrentBidderID || winning.Amount != *auction.CurrentBid {
+			return errors.New("current bid does not match the recorded bids")
+		}
+
+		// The player's previous team may match the winning bid before the sale goes through
+		if offer, err = s.openRTM(ctx, tx, auction, player, winning); err != nil || offer != nil {
+			return err
+		}
+
+		sale, err = s.finaliseSale(ctx, tx, auction, winning, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return player, team, nil
+	return s.saleResult(ctx, auction, sale, offer), nil
 }
 
 // SellToTeam manually allocates the current player to a specific team (for tie-breaking at max bid)
-func (s *AuctionService) SellToTeam(ctx context.Context, teamID uuid.UUID) (*models.Player, *models.Team, error) {
-	auction, err := s.repos.Auctions.GetCurrent(ctx)
+func (s *AuctionService) SellToTeam(ctx context.Context, teamID uuid.UUID) (*SaleResult, error) {
+	defer s.InvalidateState(ctx)
+
+	maxBid := s.BidLadder(ctx).Max()
+	home := s.homeCountry(ctx)
+
+	var sale *models.Sale
+	var offer *models.RTMOffer
+	var auction *models.Auction
+	err := s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		var err error
+		auction, err = tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return errors.New("no active auction")
+		}
+
+		if auction.CurrentPlayerID == nil || auction.CurrentBid == nil {
+			return errors.New("no player currently on block")
+		}
+		if auction.RTMTeamID != nil {
+			return errors.New("waiting for the former team to use or decline its right to match")
+		}
+
+		// Verify the bid is at max (only allow manual allocation for ties at the top of the ladder)
+		if *auction.CurrentBid != maxBid {
+			return errors.New("manual allocation only allowed at max bid (tie-breaking)")
+		}
+
+		// Verify the team actually bid the max on this player
+		bids, err := tx.Bids.FindByPlayer(ctx, *auction.CurrentPlayerID)
+		if err != nil {
+			return err
+		}
+
+		var winning *models.Bid
+		for i := range bids {
+			if !bids[i].Voided && bids[i].TeamID == teamID && bids[i].Amount == maxBid {
+				winning = &bids[i]
+				break
+			}
+		}
+		if winning == nil {
+			return fmt.Errorf("team did not bid %d on this player", maxBid)
+		}
+
+		// Verify team has sufficient budget
+		team, err := tx.Teams.FindByID(ctx, teamID)
+		if err != nil {
+			return errors.New("team not found")
+		}
+		remainingBudget := team.Budget - team.Spent
+		if maxBid > remainingBudget {
+			return errors.New("team has insufficient budget")
+		}
+
+		player, err := tx.Players.FindByID(ctx, *auction.CurrentPlayerID)
+		if err != nil {
+			return errors.New("player not found")
+		}
+		if err := checkSquadLimits(team, player, home); err != nil {
+			return err
+		}
+
+		// The player's previous team may match the winning bid before the sale goes through;
+		// the allocated team becomes the leading bidder the window is held for
+		if offer, err = s.openRTM(ctx, tx, auction, player, winning); err != nil || offer != nil {
+			if err == nil {
+				err = tx.Auctions.UpdateCurrentBid(ctx, auction.ID, winning.Amount, winning.TeamID)
+			}
+			return err
+		}
+
+		sale, err = s.finaliseSale(ctx, tx, auction, winning, false)
+		return err
+	})
 	if err != nil {
-		return nil, nil, errors.New("no active auction")
+		return nil, err
 	}
 
-	if auction.CurrentPlayerID == nil || auction.CurrentBid == nil {
-		return nil, nil, errors.New("no player currently on block")
-	}
+	return s.saleResult(ctx, auction, sale, offer), nil
+}
 
-	// Verify the bid is at max (only allow manual allocation for ties at 50000)
-	if *auction.CurrentBid != maxBid {
-		return nil, nil, errors.New("manual allocation only allowed at max bid (tie-breaking)")
+// saleResult stops the countdown once a lot is sold, or restarts it for the
+// right-to-match window the hammer opened, and loads what was sold to whom
+func (s *AuctionService) saleResult(ctx context.Context, auction *models.Auction, sale *models.Sale, offer *models.RTMOffer) *SaleResult {
+	if offer != nil {
+		s.restartLotTimer(ctx, auction)
+		return &SaleResult{RTM: offer}
 	}
 
-	// Verify the team actually bid 50000 on this player
-	bids, err := s.repos.Bids.FindByPlayer(ctx, *auction.CurrentPlayerID)
-	if err != nil {
-		return nil, nil, err
+	s.StopTimer()
+
+	// Get sold player and team info
+	player, _ := s.repos.Players.FindByID(ctx, sale.PlayerID)
+	team, _ := s.repos.Teams.FindByID(ctx, sale.TeamID)
+
+	return &SaleResult{Player: player, Team: team}
+}
+
+// finaliseSale applies a sale inside the caller's transaction: the player is marked
+// sold, the team's purse is charged, the winning bid is flagged, a sale record is
+// written and the lot is cleared. Either all of it happens or none of it does. rtm marks
+// a sale the player's previous team took with a right-to-match card.
+func (s *AuctionService) finaliseSale(ctx context.Context, tx *repository.Repositories, auction *models.Auction, winning *models.Bid, rtm bool) (*models.Sale, error) {
+	if err := tx.Players.MarkSold(ctx, winning.PlayerID, winning.TeamID, winning.Amount); err != nil {
+		return nil, err
 	}
 
-	teamBid50000 := false
-	for _, bid := range bids {
-		if bid.TeamID == teamID && bid.Amount == maxBid {
-			teamBid50000 = true
-			break
-		}
+	if err := tx.Teams.UpdateSpent(ctx, winning.TeamID, winning.Amount); err != nil {
+		return nil, err
 	}
-	if !teamBid50000 {
-		return nil, nil, errors.New("team did not bid 50000 on this player")
+
+	if err := tx.Bids.MarkWinning(ctx, winning.ID); err != nil {
+		return nil, err
 	}
 
-	// Verify team has sufficient budget
-	team, err := s.repos.Teams.FindByID(ctx, teamID)
-	if err != nil {
-		return nil, nil, errors.New("team not found")
+	sale := &models.Sale{
+		AuctionID: auction.ID,
+		PlayerID:  winning.PlayerID,
+		TeamID:    winning.TeamID,
+		BidID:     &winning.ID,
+		Amount:    winning.Amount,
+		RTM:       rtm,
 	}
-	remainingBudget := team.Budget - team.Spent
-	if maxBid > remainingBudget {
-		return nil, nil, errors.New("team has insufficient budget")
+	if err := tx.Sales.Create(ctx, sale); err != nil {
+		return nil, err
 	}
 
-	s.StopTimer()
-
-	// Mark player as sold to the specified team
-	if err := s.repos.Players.MarkSold(ctx, *auction.CurrentPlayerID, teamID, maxBid); err != nil {
-		return nil, nil, err
+	if err := tx.Auctions.ClearCurrentPlayer(ctx, auction.ID); err != nil {
+		return nil, err
 	}
 
-	// Update team spent
-	if err := s.repos.Teams.UpdateSpent(ctx, teamID, maxBid); err != nil {
-		return nil, nil, err
+	return sale, nil
+}
+
+// UnsellResult describes a reversed sale
+type UnsellResult struct {
+	Player *models.Player `json:"player"`
+	Team   *models.Team   `json:"team"`
+	Sale   *models.Sale   `json:"sale,omitempty"`
+	Amount int64          `json:"amount"`
+	Mode   string         `json:"mode"`
+}
+
+// UnsellPlayer reverses a completed sale: the team is refunded, the sale record is
+// marked reversed and its bids voided (both kept for audit), and the player either
+// returns to the end of the queue (mode "queue") or goes straight back on the block (mode "block")
+func (s *AuctionService) UnsellPlayer(ctx context.Context, playerID uuid.UUID, mode string, reversedBy *uuid.UUID, reason string) (*UnsellResult, error) {
+	defer s.InvalidateState(ctx)
+
+	if mode == "" {
+		mode = "queue"
+	}
+	if mode != "queue" && mode != "block" {
+		return nil, errors.New("mode must be 'queue' or 'block'")
+	}
+	var reasonPtr *string
+	if reason = strings.TrimSpace(reason); reason != "" {
+		reasonPtr = &reason
 	}
 
-	// Get sold player info
-	player, _ := s.repos.Players.FindByID(ctx, *auction.CurrentPlayerID)
+	result := &UnsellResult{Mode: mode}
+	var auction *models.Auction
+	err := s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		var err error
+		auction, err = tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return errors.New("no active auction")
+		}
 
-	// Clear current player from auction
-	if err := s.repos.Auctions.ClearCurrentPlayer(ctx, auction.ID); err != nil {
-		return nil, nil, err
+		player, err := tx.Players.FindByID(ctx, playerID)
+		if err != nil {
+			return errors.New("player not found")
+		}
+		if player.Status != "sold" || player.TeamID == nil {
+			return errors.New("player is not sold")
+		}
+
+		if mode == "block" && auction.CurrentPlayerID != nil {
+			return errors.New("another player is on the block - close that lot first")
+		}
+
+		// Sales made before sale records existed only live on the player row
+		teamID := *player.TeamID
+		var amount int64
+		if player.SoldPrice != nil {
+			amount = *player.SoldPrice
+		}
+		sale, err := tx.Sales.FindActiveByPlayerForUpdate(ctx, playerID)
+		if err == nil {
+			teamID = sale.TeamID
+			amount = sale.Amount
+			if err := tx.Sales.Reverse(ctx, sale, reversedBy, reasonPtr); err != nil {
+				return err
+			}
+			result.Sale = sale
+		}
+
+		// Refund the purse
+		if err := tx.Teams.UpdateSpent(ctx, teamID, -amount); err != nil {
+			return err
+		}
+
+		if err := tx.Bids.VoidForPlayer(ctx, playerID); err != nil {
+			return err
+		}
+
+		if err := tx.Players.RevertSale(ctx, playerID); err != nil {
+			return err
+		}
+
+		if mode == "block" {
+			if err := tx.Auctions.SetCurrentPlayer(ctx, auction.ID, playerID, player.BasePrice); err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Players.SkipPlayer(ctx, playerID); err != nil {
+				return err
+			}
+		}
+
+		result.Amount = amount
+		result.Team, err = tx.Teams.FindByID(ctx, teamID)
+		if err != nil {
+			return errors.New("team not found")
+		}
+		result.Player, err = tx.Players.FindByID(ctx, playerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return player, team, nil
+	if mode == "block" {
+		s.restartLotTimer(ctx, auction)
+	}
+
+	return result, nil
 }
 
 // MarkUnsold marks the current player as unsold
 func (s *AuctionService) MarkUnsold(ctx context.Context) (*models.Player, error) {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return nil, errors.New("no active auction")
@@ -528,6 +761,9 @@ func (s *AuctionService) MarkUnsold(ctx context.Context) (*models.Player, error)
 	if auction.CurrentPlayerID == nil {
 		return nil, errors.New("no player currently on block")
 	}
+	if auction.RTMTeamID != nil {
+		return nil, errors.New("cannot mark a player unsold while a right-to-match window is open")
+	}
 
 	s.StopTimer()
 
@@ -548,6 +784,8 @@ func (s *AuctionService) MarkUnsold(ctx context.Context) (*models.Player, error)
 
 // SkipPlayer skips the current player and returns them to the queue
 func (s *AuctionService) SkipPlayer(ctx context.Context) (*models.Player, error) {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return nil, errors.New("no active auction")
@@ -556,6 +794,9 @@ func (s *AuctionService) SkipPlayer(ctx context.Context) (*models.Player, error)
 	if auction.CurrentPlayerID == nil {
 		return nil, errors.New("no player currently on block")
 	}
+	if auction.RTMTeamID != nil {
+		return nil, errors.New("cannot skip a player while a right-to-match window is open")
+	}
 
 	s.StopTimer()
 
@@ -580,7 +821,6 @@ func (s *AuctionService) SkipPlayer(ctx context.Context) (*models.Player, error)
 	return player, nil
 }
 
-
 // ResetTimer resets the bid timer
 func (s *AuctionService) ResetTimer(ctx context.Context) (int, error) {
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
@@ -588,12 +828,14 @@ func (s *AuctionService) ResetTimer(ctx context.Context) (int, error) {
 		return 0, errors.New("no active auction")
 	}
 
-	s.redis.Set(ctx, "auction:timer_remaining", auction.TimerDuration, 0)
+	s.restartLotTimer(ctx, auction)
 	return auction.TimerDuration, nil
 }
 
 // UndoBid removes the last bid for the current player
 func (s *AuctionService) UndoBid(ctx context.Context) error {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
 		return errors.New("no active auction")
@@ -602,6 +844,9 @@ func (s *AuctionService) UndoBid(ctx context.Context) error {
 	if auction.CurrentPlayerID == nil {
 		return errors.New("no player currently on block")
 	}
+	if auction.RTMTeamID != nil {
+		return errors.New("cannot undo a bid while a right-to-match window is open")
+	}
 
 	playerID := *auction.CurrentPlayerID
 
@@ -627,85 +872,79 @@ func (s *AuctionService) UndoBid(ctx context.Context) error {
 	return s.repos.Auctions.Update(ctx, auction)
 }
 
-
 // GetBidHistory returns bid history for a player
 func (s *AuctionService) GetBidHistory(ctx context.Context, playerID uuid.UUID) ([]models.Bid, error) {
 	return s.repos.Bids.FindByPlayer(ctx, playerID)
 }
 
-// StartTimer starts the countdown timer
-func (s *AuctionService) StartTimer(ctx context.Context, onTick func(remaining int), onComplete func()) {
-	s.timerLock.Lock()
-	defer s.timerLock.Unlock()
-
-	// Cancel existing timer
-	if s.timerCancel != nil {
-		s.timerCancel()
+// CloseExpiredLot is called when the countdown reaches zero. With auto-close enabled
+// it sells the player to the leading bidder, or marks them unsold if nobody bid;
+// otherwise the lot stays open for the host's hammer. An open right-to-match window
+// lapses and the player goes to the winning bidder.
+func (s *AuctionService) CloseExpiredLot(ctx context.Context) (*LotCloseResult, error) {
+	auction, err := s.repos.Auctions.GetCurrent(ctx)
+	if err != nil {
+		return nil, errors.New("no active auction")
+	}
+	if auction.CurrentPlayerID == nil {
+		return nil, errors.New("no player currently on block")
+	}
+	if auction.Status != "live" {
+		return &LotCloseResult{Closed: false}, nil
 	}
 
-	timerCtx, cancel := context.WithCancel(ctx)
-	s.timerCancel = cancel
-	s.redis.Set(ctx, "auction:timer_running", true, 0)
+	// An unanswered right to match lapses whether or not lots close automatically
+	if auction.RTMTeamID != nil {
+		result, err := s.closeRTM(ctx, nil, RTMLapsed)
+		if err != nil {
+			return nil, err
+		}
+		return &LotCloseResult{Closed: true, Sold: true, Player: result.Player, Team: result.Team, Lapsed: result}, nil
+	}
+	if !auction.AutoClose {
+		return &LotCloseResult{Closed: false}, nil
+	}
 
-	go func() {
-		// Panic recovery to prevent timer goroutine from crashing the server
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("CRITICAL: Timer goroutine panic recovered: %v", r)
-				// Ensure timer state is set to stopped on panic
-				s.redis.Set(context.Background(), "auction:timer_running", false, 0)
-			}
-		}()
-
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-timerCtx.Done():
-				return
-			case <-ticker.C:
-				// Panic recovery for each tick operation
-				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							log.Printf("Error in timer tick operation: %v", r)
-						}
-					}()
-					remaining, _ := s.redis.Get(context.Background(), "auction:timer_remaining").Int()
-					if remaining <= 0 {
-						s.redis.Set(context.Background(), "auction:timer_running", false, 0)
-						if onComplete != nil {
-							onComplete()
-						}
-						return
-					}
-					remaining--
-					s.redis.Set(context.Background(), "auction:timer_remaining", remaining, 0)
-					if onTick != nil {
-						onTick(remaining)
-					}
-				}()
-			}
+	if auction.CurrentBidderID == nil {
+		player, err := s.MarkUnsold(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}()
+		return &LotCloseResult{Closed: true, Sold: false, Player: player}, nil
+	}
+
+	sale, err := s.SellPlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sale.RTM != nil {
+		return &LotCloseResult{Closed: false, Player: sale.RTM.Player, RTM: sale.RTM}, nil
+	}
+	return &LotCloseResult{Closed: true, Sold: true, Player: sale.Player, Team: sale.Team}, nil
 }
 
-// StopTimer stops the countdown timer
-func (s *AuctionService) StopTimer() {
-	s.timerLock.Lock()
-	defer s.timerLock.Unlock()
+// SetAutoClose chooses whether the current auction closes lots automatically on timer expiry
+func (s *AuctionService) SetAutoClose(ctx context.Context, enabled bool) error {
+	defer s.InvalidateState(ctx)
 
-	if s.timerCancel != nil {
-		s.timerCancel()
-		s.timerCancel = nil
+	auction, err := s.repos.Auctions.GetCurrent(ctx)
+	if err != nil {
+		return errors.New("no active auction")
 	}
-	s.redis.Set(context.Background(), "auction:timer_running", false, 0)
+	return s.repos.Auctions.UpdateAutoClose(ctx, auction.ID, enabled)
 }
 
 // ResetAuction completely resets the auction - clears all bids, player statuses, team spent amounts
-// Optimized with transaction for atomicity and performance
+// after archiving any results not archived yet.
+// Optimized with transaction for atomicity and performance. Only the auction the service
+// is bound to is reset, and it goes back to pending.
 func (s *AuctionService) ResetAuction(ctx context.Context) error {
+	auctionID, err := s.resetScope()
+	if err != nil {
+		return err
+	}
+	defer s.InvalidateState(ctx)
+
 	// Stop any running timer first (non-blocking)
 	s.StopTimer()
 
@@ -715,12 +954,18 @@ func (s *AuctionService) ResetAuction(ctx context.Context) error {
 		return err
 	}
 	defer tx.Rollback(ctx)
+	txRepos := s.repos.InTx(tx)
 
-	// Execute all reset operations in a single transaction
-	// This reduces round trips and ensures atomicity
-	
-	// 1. Delete all bids (fastest operation, do first)
-	if _, err := tx.Exec(ctx, "DELETE FROM bids"); err != nil {
+	// Archive the season first, so its results outlive the reset
+	if err := archiveBeforeReset(ctx, txRepos, archiveReset); err != nil {
+		return err
+	}
+
+	// 1. Delete all sale records and bids (fastest operations, do first)
+	if _, err := tx.Exec(ctx, "DELETE FROM sales WHERE auction_id = $1", auctionID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM bids WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
 
@@ -733,22 +978,19 @@ func (s *AuctionService) ResetAuction(ctx context.Context) error {
 			sold_at = NULL, 
 			badge = NULL, 
 			updated_at = NOW()
-	`); err != nil {
+		WHERE auction_id = $1
+	`, auctionID); err != nil {
 		return err
 	}
 
 	// 3. Reset all team spent amounts to 0 (bulk update)
-	if _, err := tx.Exec(ctx, "UPDATE teams SET spent = 0, updated_at = NOW()"); err != nil {
+	if _, err := tx.Exec(ctx, "UPDATE teams SET spent = 0, updated_at = NOW() WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
 
-	// 4. Clear player references from auctions before deletion
-	if _, err := tx.Exec(ctx, "UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL WHERE status IN ('live', 'paused', 'pending')"); err != nil {
-		return err
-	}
-
-	// 5. Delete current auction records
-	if _, err := tx.Exec(ctx, "DELETE FROM auctions WHERE status IN ('live', 'paused', 'pending')"); err != nil {
+	// 4. Put the auction back to pending with nobody on the block. Auctions own
+	// their teams and players, so they are restarted rather than deleted.
+	if err := txRepos.Auctions.Restart(ctx, auctionID); err != nil {
 		return err
 	}
 
@@ -758,14 +1000,22 @@ func (s *AuctionService) ResetAuction(ctx context.Context) error {
 	}
 
 	// Clear Redis state (non-critical, don't fail if this fails)
-	s.redis.Del(ctx, "auction:timer_remaining", "auction:timer_running", "auction:bid_freeze")
+	s.redis.Del(ctx, s.liveKeys()...)
 
 	return nil
 }
 
-// ResetEverything performs a complete reset - deletes all teams, players, bids, and auctions
-// Optimized with transaction for atomicity and performance
+// ResetEverything performs a complete reset - deletes the auction's teams, players, bids and
+// sales after archiving any results not archived yet.
+// Optimized with transaction for atomicity and performance. Only the auction the service
+// is bound to is reset; the auction itself is kept, back at pending.
 func (s *AuctionService) ResetEverything(ctx context.Context) error {
+	auctionID, err := s.resetScope()
+	if err != nil {
+		return err
+	}
+	defer s.InvalidateState(ctx)
+
 	// Stop any running timer first (non-blocking)
 	s.StopTimer()
 
@@ -775,32 +1025,36 @@ func (s *AuctionService) ResetEverything(ctx context.Context) error {
 		return err
 	}
 	defer tx.Rollback(ctx)
+	txRepos := s.repos.InTx(tx)
+
+	// Archive the season first, so its results outlive the reset
+	if err := archiveBeforeReset(ctx, txRepos, archiveResetEverything); err != nil {
+		return err
+	}
 
 	// Execute all delete operations in a single transaction
 	// Order matters due to foreign key constraints
-	
-	// 1. Delete all bids first (no dependencies)
-	if _, err := tx.Exec(ctx, "DELETE FROM bids"); err != nil {
+
+	// 1. Delete all sale records and bids first (no dependencies)
+	if _, err := tx.Exec(ctx, "DELETE FROM sales WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
-
-	// 2. Clear ALL player references from ALL auctions (critical for FK constraints)
-	if _, err := tx.Exec(ctx, "UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL"); err != nil {
+	if _, err := tx.Exec(ctx, "DELETE FROM bids WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
 
-	// 3. Delete all players (safe now that references are cleared)
-	if _, err := tx.Exec(ctx, "DELETE FROM players"); err != nil {
+	// 2. Restart the auction, which also clears its player and bidder references (critical for FK constraints)
+	if err := txRepos.Auctions.Restart(ctx, auctionID); err != nil {
 		return err
 	}
 
-	// 4. Delete all auctions (including completed ones)
-	if _, err := tx.Exec(ctx, "DELETE FROM auctions"); err != nil {
+	// 3. Delete all players (safe now that references are cleared)
+	if _, err := tx.Exec(ctx, "DELETE FROM players WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
 
-	// 5. Delete all teams (last, as players reference teams)
-	if _, err := tx.Exec(ctx, "DELETE FROM teams"); err != nil {
+	// 4. Delete all teams (last, as players reference teams)
+	if _, err := tx.Exec(ctx, "DELETE FROM teams WHERE auction_id = $1", auctionID); err != nil {
 		return err
 	}
 
@@ -810,30 +1064,36 @@ func (s *AuctionService) ResetEverything(ctx context.Context) error {
 	}
 
 	// Clear Redis state (non-critical, don't fail if this fails)
-	s.redis.Del(ctx, "auction:timer_remaining", "auction:timer_running", "auction:bid_freeze")
+	s.redis.Del(ctx, s.liveKeys()...)
 
 	return nil
 }
 
+// resetScope returns the auction a reset is limited to. A service bound to no auction
+// would reset every tenant's data, so it refuses.
+func (s *AuctionService) resetScope() (uuid.UUID, error) {
+	if id := s.repos.AuctionID(); id != nil {
+		return *id, nil
+	}
+	return uuid.Nil, errors.New("a reset must be limited to one auction")
+}
+
 // SetLiveStatus sets the auction to live status (for broadcasting "Go Live")
 func (s *AuctionService) SetLiveStatus(ctx context.Context) error {
+	defer s.InvalidateState(ctx)
+
 	auction, err := s.repos.Auctions.GetCurrent(ctx)
 	if err != nil {
+		if s.repos.AuctionID() != nil {
+			return ErrAuctionNotFound
+		}
 		// Create new auction if none exists
 		settings, _ := s.repos.Settings.GetAll(ctx)
-		timerDuration := 30
-		if td, ok := settings["timer_duration"].(float64); ok {
-			timerDuration = int(td)
-		}
+		return s.repos.Auctions.Create(ctx, newAuction(settings, "live"))
+	}
 
-		auction = &models.Auction{
-			Name:          "Auction Session",
-			Season:        "2026",
-			Status:        "live",
-			TimerDuration: timerDuration,
-			Round:         1,
-		}
-		return s.repos.Auctions.Create(ctx, auction)
+	if auction.Status == "completed" {
+		return ErrAuctionCompleted
 	}
 
 	// Update existing auction to live status
@@ -842,5 +1102,6 @@ func (s *AuctionService) SetLiveStatus(ctx context.Context) error {
 
 // SetBidderBiddingDisabled sets whether bidder bidding is disabled
 func (s *AuctionService) SetBidderBiddingDisabled(ctx context.Context, disabled bool) {
-	s.redis.Set(ctx, "auction:bidder_bidding_disabled", disabled, 0)
+	s.redis.Set(ctx, s.key(bidderBiddingDisabledKey), disabled, 0)
+	s.InvalidateState(ctx)
 }
@@ -0,0 +1,667 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auctionapp/backend/internal/database"
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// newTestAuctionService connects to a disposable Postgres and Redis.
+// The test wipes all auction data, so it only runs when pointed at test instances.
+func newTestAuctionService(t *testing.T) (*AuctionService, *repository.Repositories) {
+	t.Helper()
+
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	redisURL := os.Getenv("TEST_REDIS_URL")
+	if dbURL == "" || redisURL == "" {
+		t.Skip("set TEST_DATABASE_URL and TEST_REDIS_URL to run against disposable databases")
+	}
+
+	db, err := database.Connect(dbURL)
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	t.Cleanup(db.Close)
+	if err := database.RunMigrations(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	rdb, err := database.ConnectRedis(redisURL)
+	if err != nil {
+		t.Fatalf("connect redis: %v", err)
+	}
+	t.Cleanup(func() { rdb.Close() })
+
+	// Resets only ever touch one auction, so the test database is wiped directly
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"DELETE FROM sales",
+		"DELETE FROM bids",
+		"UPDATE auctions SET current_player_id = NULL, current_bidder_id = NULL, rtm_team_id = NULL",
+		"DELETE FROM players",
+		"DELETE FROM auctions",
+		"DELETE FROM teams",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("wipe: %v", err)
+		}
+	}
+	if err := rdb.FlushDB(ctx).Err(); err != nil {
+		t.Fatalf("flush redis: %v", err)
+	}
+
+	repos := repository.NewRepositories(db)
+	return NewAuctionService(repos, rdb), repos
+}
+
+// auctionFixture is a pending auction created for a test, with repositories and an
+// auction service bound to it
+type auctionFixture struct {
+	t       *testing.T
+	auction *models.Auction
+	repos   *repository.Repositories
+	svc     *AuctionService
+}
+
+// newAuctionFixture creates an auction next to the ones root can see
+func newAuctionFixture(t *testing.T, root *AuctionService, name, season string) *auctionFixture {
+	t.Helper()
+	auction := newAuction(map[string]interface{}{"tournament_name": name, "season": season}, "pending")
+	if err := root.repos.Auctions.Create(context.Background(), auction); err != nil {
+		t.Fatalf("create auction: %v", err)
+	}
+	scoped := root.repos.ForAuction(auction.ID)
+	return &auctionFixture{t: t, auction: auction, repos: scoped, svc: NewAuctionService(scoped, root.redis)}
+}
+
+// team creates a team in the auction. Colour and squad caps default to a black team of
+// 25 with 8 overseas players.
+func (f *auctionFixture) team(team *models.Team) *models.Team {
+	f.t.Helper()
+	if team.Color == "" {
+		team.Color = "#000000"
+	}
+	if team.MaxPlayers == 0 {
+		team.MaxPlayers = 25
+	}
+	if team.MaxForeign == 0 {
+		team.MaxForeign = 8
+	}
+	if err := f.repos.Teams.Create(context.Background(), team); err != nil {
+		f.t.Fatalf("create team: %v", err)
+	}
+	return team
+}
+
+// player creates a player in the auction. Role, category and stats default to a Set 1
+// batsman without stats.
+func (f *auctionFixture) player(player *models.Player) *models.Player {
+	f.t.Helper()
+	if player.Role == "" {
+		player.Role = "Batsman"
+	}
+	if player.Category == "" {
+		player.Category = "Set 1"
+	}
+	if player.Stats == nil {
+		player.Stats = map[string]interface{}{}
+	}
+	if err := f.repos.Players.Create(context.Background(), player); err != nil {
+		f.t.Fatalf("create player: %v", err)
+	}
+	return player
+}
+
+func TestPlaceBidConcurrentBidders(t *testing.T) {
+	testConcurrentBidders(t, 8)
+}
+
+// TestPlaceBidMoreBiddersThanConnections races more bidders than the pool has connections.
+// Bids waiting on the auction lock then hold every connection, so the bid holding the lock
+// must not need another one.
+func TestPlaceBidMoreBiddersThanConnections(t *testing.T) {
+	testConcurrentBidders(t, database.MaxConns+5)
+}
+
+// testConcurrentBidders has teamCount teams race to bid each of the first ladder rungs
+func testConcurrentBidders(t *testing.T, teamCount int) {
+	svc, repos := newTestAuctionService(t)
+	ctx := context.Background()
+
+	teamIDs := make([]uuid.UUID, teamCount)
+	for i := range teamIDs {
+		team := &models.Team{
+			Name:       fmt.Sprintf("Team %d", i+1),
+			ShortName:  fmt.Sprintf("T%d", i+1),
+			Color:      "#000000",
+			Budget:     10_000_000,
+			MaxPlayers: 25,
+			MaxForeign: 8,
+		}
+		if err := repos.Teams.Create(ctx, team); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		teamIDs[i] = team.ID
+	}
+
+	ladder := svc.BidLadder(ctx)
+	player := &models.Player{
+		Name:      "Test Player",
+		Country:   "India",
+		Role:      "Batsman",
+		BasePrice: ladder[0],
+		Category:  "Set 1",
+		Stats:     map[string]interface{}{},
+	}
+	if err := repos.Players.Create(ctx, player); err != nil {
+		t.Fatalf("create player: %v", err)
+	}
+
+	if _, err := svc.StartAuction(ctx); err != nil {
+		t.Fatalf("start auction: %v", err)
+	}
+	if _, err := svc.StartBidForPlayer(ctx, player.ID); err != nil {
+		t.Fatalf("start player: %v", err)
+	}
+
+	// Every team races to bid the same rung; exactly one bid per rung may win.
+	// The freeze window is skipped so only the database lock serialises bids.
+	rounds := 5
+	if len(ladder) < rounds {
+		rounds = len(ladder)
+	}
+	for round := 0; round < rounds; round++ {
+		amount := ladder[round]
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		accepted := 0
+		start := make(chan struct{})
+		for _, teamID := range teamIDs {
+			wg.Add(1)
+			go func(teamID uuid.UUID) {
+				defer wg.Done()
+				<-start
+				if _, err := svc.PlaceBid(ctx, teamID, amount, true, true); err == nil {
+					mu.Lock()
+					accepted++
+					mu.Unlock()
+				}
+			}(teamID)
+		}
+		began := time.Now()
+		close(start)
+		wg.Wait()
+
+		// A stalled round only ends when the bids time out
+		if elapsed := time.Since(began); elapsed > 5*time.Second {
+			t.Fatalf("round %d took %s - bids stalled waiting for connections", round, elapsed)
+		}
+		if accepted != 1 {
+			t.Fatalf("round %d: %d bids accepted at %d, want exactly 1", round, accepted, amount)
+		}
+	}
+
+	// Bids must be totally ordered by seq with strictly increasing amounts,
+	// and the auction row must agree with the last accepted bid
+	bids, err := repos.Bids.FindByPlayer(ctx, player.ID)
+	if err != nil {
+		t.Fatalf("find bids: %v", err)
+	}
+	if len(bids) != rounds {
+		t.Fatalf("got %d bids, want %d", len(bids), rounds)
+	}
+	for i := 1; i < len(bids); i++ {
+		newer, older := bids[i-1], bids[i]
+		if newer.Seq <= older.Seq || newer.Amount <= older.Amount {
+			t.Fatalf("bids out of order: seq %d amount %d after seq %d amount %d",
+				newer.Seq, newer.Amount, older.Seq, older.Amount)
+		}
+		if newer.TeamID == older.TeamID {
+			t.Fatalf("team %s bid twice in a row", newer.TeamID)
+		}
+	}
+
+	auction, err := repos.Auctions.GetCurrent(ctx)
+	if err != nil {
+		t.Fatalf("get auction: %v", err)
+	}
+	last := bids[0]
+	if auction.CurrentBid == nil || *auction.CurrentBid != last.Amount {
+		t.Fatalf("auction current bid %v, want %d", auction.CurrentBid, last.Amount)
+	}
+	if auction.CurrentBidderID == nil || *auction.CurrentBidderID != last.TeamID {
+		t.Fatalf("auction current bidder %v, want %s", auction.CurrentBidderID, last.TeamID)
+	}
+}
+
+func TestAuctionsAreIsolated(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+
+	// Two tournaments on one deployment, each with a team of its own
+	var fixtures [2]*auctionFixture
+	var scoped [2]*repository.Repositories
+	var teamIDs [2]uuid.UUID
+	for i := range fixtures {
+		f := newAuctionFixture(t, root, "League "+string(rune('A'+i)), "")
+		fixtures[i], scoped[i] = f, f.repos
+		teamIDs[i] = f.team(&models.Team{Name: f.auction.Name + " XI", ShortName: "T" + string(rune('A'+i)), Budget: 150_000}).ID
+	}
+
+	for i, r := range scoped {
+		teams, err := r.Teams.FindAll(ctx)
+		if err != nil {
+			t.Fatalf("find teams: %v", err)
+		}
+		if len(teams) != 1 || teams[0].ID != teamIDs[i] {
+			t.Fatalf("auction %d sees %d teams, want only its own", i, len(teams))
+		}
+		if _, err := r.Teams.FindByID(ctx, teamIDs[1-i]); err == nil {
+			t.Fatalf("auction %d found the other auction's team", i)
+		}
+	}
+
+	// A setting changed for one auction leaves the other on the default
+	if err := scoped[0].Settings.Set(ctx, "home_country", "Australia"); err != nil {
+		t.Fatalf("set setting: %v", err)
+	}
+	if got, _ := scoped[0].Settings.Get(ctx, "home_country"); got != "Australia" {
+		t.Fatalf("auction 0 home_country %v, want Australia", got)
+	}
+	if got, _ := scoped[1].Settings.Get(ctx, "home_country"); got != "India" {
+		t.Fatalf("auction 1 home_country %v, want the India default", got)
+	}
+
+	// A player's bids are only found through the player's auction
+	player := fixtures[0].player(&models.Player{Name: "Jane Doe", Country: "India", BasePrice: 2000})
+	bid := &models.Bid{AuctionID: fixtures[0].auction.ID, PlayerID: player.ID, TeamID: teamIDs[0], Amount: 2000}
+	if err := scoped[0].Bids.Create(ctx, bid); err != nil {
+		t.Fatalf("create bid: %v", err)
+	}
+	if bids, err := scoped[0].Bids.FindByPlayer(ctx, player.ID); err != nil || len(bids) != 1 {
+		t.Fatalf("auction 0 found %d bids (%v), want 1", len(bids), err)
+	}
+	if bids, err := scoped[1].Bids.FindByPlayer(ctx, player.ID); err != nil || len(bids) != 0 {
+		t.Fatalf("auction 1 found %d of the other auction's bids (%v)", len(bids), err)
+	}
+
+	// Each auction's live state lives under its own Redis keys
+	a, b := fixtures[0].svc, fixtures[1].svc
+	if a.key(timerDeadlineKey) == b.key(timerDeadlineKey) {
+		t.Fatalf("auctions share the timer key %s", a.key(timerDeadlineKey))
+	}
+}
+
+// TestForeignCountMatchesIsOverseas checks the overseas count the team queries compute
+// agrees with isOverseas, whichever of the player's and home country is stored as a flag
+func TestForeignCountMatchesIsOverseas(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+
+	for _, home := range []string{"India", "🇮🇳", " india "} {
+		f := newAuctionFixture(t, root, "Home "+home, "2026")
+		if err := f.repos.Settings.Set(ctx, "home_country", home); err != nil {
+			t.Fatalf("set home_country: %v", err)
+		}
+		team := f.team(&models.Team{Name: "Home XI", ShortName: "HXI", Budget: 150_000})
+
+		want := 0
+		for i, country := range []string{"India", "🇮🇳", "Australia", "🇦🇺", "England"} {
+			player := f.player(&models.Player{Name: fmt.Sprintf("Player %d", i), Country: country, BasePrice: 2000})
+			if err := f.repos.Players.MarkSold(ctx, player.ID, team.ID, 2000); err != nil {
+				t.Fatalf("sell player: %v", err)
+			}
+			if isOverseas(player, home) {
+				want++
+			}
+		}
+		if want != 3 {
+			t.Fatalf("isOverseas counted %d overseas players with home %q, want 3", want, home)
+		}
+
+		got, err := f.repos.Teams.FindByID(ctx, team.ID)
+		if err != nil {
+			t.Fatalf("find team: %v", err)
+		}
+		if got.ForeignCount != want {
+			t.Errorf("home %q: ForeignCount = %d, want %d", home, got.ForeignCount, want)
+		}
+	}
+}
+
+// TestResetNeedsAnAuction checks a service bound to no auction refuses to reset, before
+// touching the database
+func TestResetNeedsAnAuction(t *testing.T) {
+	svc := NewAuctionService(repository.NewRepositories(nil), nil)
+	if err := svc.ResetAuction(context.Background()); err == nil {
+		t.Error("ResetAuction on an unbound service succeeded, want an error")
+	}
+	if err := svc.ResetEverything(context.Background()); err == nil {
+		t.Error("ResetEverything on an unbound service succeeded, want an error")
+	}
+}
+
+// TestCompletedAuctionStaysCompleted checks an ended auction is not revived by the
+// status transitions, which see a bound auction whatever its status
+func TestCompletedAuctionStaysCompleted(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+	f := newAuctionFixture(t, root, "Ended League", "2026")
+
+	if _, err := f.svc.StartAuction(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := f.svc.EndAuction(ctx); err != nil {
+		t.Fatalf("end: %v", err)
+	}
+
+	transitions := map[string]func() error{
+		"StartAuction":  func() error { _, err := f.svc.StartAuction(ctx); return err },
+		"PauseAuction":  func() error { return f.svc.PauseAuction(ctx) },
+		"ResumeAuction": func() error { return f.svc.ResumeAuction(ctx) },
+		"SetLiveStatus": func() error { return f.svc.SetLiveStatus(ctx) },
+	}
+	for name, transition := range transitions {
+		if err := transition(); !errors.Is(err, ErrAuctionCompleted) {
+			t.Errorf("%s on a completed auction: got %v, want ErrAuctionCompleted", name, err)
+		}
+	}
+
+	auction, err := f.repos.Auctions.FindByID(ctx, f.auction.ID)
+	if err != nil {
+		t.Fatalf("find auction: %v", err)
+	}
+	if auction.Status != "completed" {
+		t.Errorf("status = %q, want completed", auction.Status)
+	}
+}
+
+func TestOrganizationsAreIsolated(t *testing.T) {
+	_, repos := newTestAuctionService(t)
+	ctx := context.Background()
+
+	// Two leagues, each with an auction and an org admin
+	var orgs [2]*Services
+	var auctionIDs, userIDs [2]uuid.UUID
+	for i := range orgs {
+		org := &models.Organization{Name: "League " + string(rune('A'+i)), Slug: "league-" + uuid.NewString()}
+		if err := repos.Organizations.Create(ctx, org); err != nil {
+			t.Fatalf("create organization: %v", err)
+		}
+		scoped := repos.ForOrganization(org.ID)
+		orgs[i] = &Services{Users: NewUserService(scoped), Auctions: NewAuctionsService(scoped, nil)}
+
+		auction, err := orgs[i].Auctions.Create(ctx, models.CreateAuctionRequest{Name: org.Name + " Auction"})
+		if err != nil {
+			t.Fatalf("create auction: %v", err)
+		}
+		auctionIDs[i] = auction.ID
+
+		user, err := orgs[i].Users.Create(ctx, models.CreateUserRequest{
+			Email: org.Slug + "@example.com", Password: "secret123", Name: "Org Admin", Role: "org_admin",
+		})
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		if user.OrganizationID == nil || *user.OrganizationID != org.ID {
+			t.Fatalf("user created in organization %v, want %s", user.OrganizationID, org.ID)
+		}
+		userIDs[i] = user.ID
+	}
+
+	for i, svc := range orgs {
+		other := 1 - i
+		auctions, err := svc.Auctions.List(ctx)
+		if err != nil {
+			t.Fatalf("list auctions: %v", err)
+		}
+		if len(auctions) != 1 || auctions[0].ID != auctionIDs[i] {
+			t.Fatalf("organization %d sees %d auctions, want only its own", i, len(auctions))
+		}
+		if _, err := svc.Auctions.Resolve(ctx, auctionIDs[other].String(), nil); err != ErrAuctionNotFound {
+			t.Fatalf("organization %d resolved the other organization's auction: %v", i, err)
+		}
+		if _, err := svc.Users.GetByID(ctx, userIDs[other]); err == nil {
+			t.Fatalf("organization %d found the other organization's user", i)
+		}
+		if err := svc.Users.Delete(ctx, userIDs[other]); err != ErrUserNotFound {
+			t.Fatalf("organization %d deleted the other organization's user: %v", i, err)
+		}
+	}
+
+	// Nobody is made a super admin through the API
+	if _, err := orgs[0].Users.Create(ctx, models.CreateUserRequest{
+		Email: uuid.NewString() + "@example.com", Password: "secret123", Name: "Sneaky", Role: "super_admin",
+	}); err == nil {
+		t.Fatal("created a super admin inside an organization")
+	}
+}
+
+func TestResetArchivesSeason(t *testing.T) {
+	root, repos := newTestAuctionService(t)
+	ctx := context.Background()
+
+	f := newAuctionFixture(t, root, "Archive League", "2025")
+	auction, scoped, svc := f.auction, f.repos, f.svc
+	team := f.team(&models.Team{Name: "Archive XI", ShortName: "AXI", Budget: 10_000_000})
+	player := f.player(&models.Player{Name: "Jane Doe", Country: "India", BasePrice: svc.BidLadder(ctx)[0]})
+
+	// Sell the player, then reset the auction
+	if _, err := svc.StartAuction(ctx); err != nil {
+		t.Fatalf("start auction: %v", err)
+	}
+	if _, err := svc.StartBidForPlayer(ctx, player.ID); err != nil {
+		t.Fatalf("start player: %v", err)
+	}
+	if _, err := svc.PlaceBid(ctx, team.ID, player.BasePrice, true, true); err != nil {
+		t.Fatalf("place bid: %v", err)
+	}
+	if _, err := svc.SellPlayer(ctx); err != nil {
+		t.Fatalf("sell player: %v", err)
+	}
+	if err := svc.ResetAuction(ctx); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	archives := NewArchiveService(scoped)
+	list, err := archives.List(ctx, &auction.ID)
+	if err != nil {
+		t.Fatalf("list archives: %v", err)
+	}
+	if len(list) != 1 || list[0].Reason != "reset" || list[0].PlayersSold != 1 || list[0].TotalSpent != player.BasePrice {
+		t.Fatalf("archives %+v, want one reset archive with the sale", list)
+	}
+	detail, err := archives.Get(ctx, list[0].ID)
+	if err != nil {
+		t.Fatalf("get archive: %v", err)
+	}
+	if len(detail.Teams) != 1 || len(detail.Teams[0].Squad) != 1 || detail.Teams[0].Squad[0].PlayerID != player.ID {
+		t.Fatalf("archived squads %+v, want the sold player in the team's squad", detail.Teams)
+	}
+	if len(detail.Sales) != 1 {
+		t.Fatalf("got %d archived sales, want 1", len(detail.Sales))
+	}
+	if bids, err := archives.GetBids(ctx, list[0].ID, nil); err != nil || len(bids) != 1 || !bids[0].IsWinning {
+		t.Fatalf("archived bids %+v (%v), want the winning bid", bids, err)
+	}
+
+	// Nothing new to archive, so a second reset adds no archive
+	if err := svc.ResetAuction(ctx); err != nil {
+		t.Fatalf("second reset: %v", err)
+	}
+	if list, _ := archives.List(ctx, &auction.ID); len(list) != 1 {
+		t.Fatalf("got %d archives after a second reset, want 1", len(list))
+	}
+
+	// The player is found across seasons whatever the case, and archives cannot change
+	history, err := archives.PlayerHistory(ctx, " jane doe ", "INDIA")
+	if err != nil {
+		t.Fatalf("player history: %v", err)
+	}
+	if len(history.Seasons) == 0 || history.Seasons[0].ArchiveID != list[0].ID || history.Seasons[0].SoldPrice == nil {
+		t.Fatalf("player history %+v, want the archived sale first", history.Seasons)
+	}
+	if _, err := repos.GetDB().Exec(ctx, "DELETE FROM season_archives WHERE id = $1", list[0].ID); err == nil {
+		t.Fatal("deleted an archive")
+	}
+}
+
+func TestRightToMatch(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+
+	f := newAuctionFixture(t, root, "RTM League", "")
+	scoped, svc := f.repos, f.svc
+	if err := scoped.Settings.Set(ctx, RTMCardsKey, 1); err != nil {
+		t.Fatalf("set rtm_cards: %v", err)
+	}
+
+	former := f.team(&models.Team{Name: "Former XI", ShortName: "FXI", Budget: 10_000_000})
+	winner := f.team(&models.Team{Name: "Winner XI", ShortName: "WXI", Color: "#ffffff", Budget: 10_000_000})
+	player := f.player(&models.Player{Name: "John Roe", Country: "India", Role: "Bowler", BasePrice: svc.BidLadder(ctx)[0], PreviousTeamID: &former.ID})
+
+	// The hammer to another team opens the former team's window instead of selling
+	if _, err := svc.StartAuction(ctx); err != nil {
+		t.Fatalf("start auction: %v", err)
+	}
+	if _, err := svc.StartBidForPlayer(ctx, player.ID); err != nil {
+		t.Fatalf("start player: %v", err)
+	}
+	if _, err := svc.PlaceBid(ctx, winner.ID, player.BasePrice, true, true); err != nil {
+		t.Fatalf("place bid: %v", err)
+	}
+	sale, err := svc.SellPlayer(ctx)
+	if err != nil {
+		t.Fatalf("sell player: %v", err)
+	}
+	if sale.RTM == nil || sale.RTM.Team.ID != former.ID || sale.RTM.Amount != player.BasePrice || sale.RTM.CardsLeft != 1 {
+		t.Fatalf("sale %+v, want a right-to-match offer to the former team", sale)
+	}
+	if state, err := svc.GetState(ctx); err != nil || state.RTM == nil {
+		t.Fatalf("state has no right-to-match window (%v)", err)
+	}
+
+	// Bidding is closed, and only the former team may answer
+	if _, err := svc.PlaceBid(ctx, former.ID, svc.BidLadder(ctx)[1], true, true); err == nil {
+		t.Fatal("bid accepted during the right-to-match window")
+	}
+	if _, err := svc.RespondRTM(ctx, &winner.ID, true); err == nil {
+		t.Fatal("the winning team used the former team's right to match")
+	}
+
+	result, err := svc.RespondRTM(ctx, &former.ID, true)
+	if err != nil {
+		t.Fatalf("match: %v", err)
+	}
+	if result.Stage != RTMMatched || result.Team.ID != former.ID || result.WinningTeam.ID != winner.ID || result.Amount != player.BasePrice {
+		t.Fatalf("result %+v, want the former team matching the winning bid", result)
+	}
+	if got, err := scoped.Sales.FindByPlayer(ctx, player.ID); err != nil || !got.RTM || got.TeamID != former.ID {
+		t.Fatalf("sale %+v (%v), want a right-to-match sale to the former team", got, err)
+	}
+
+	// The card is spent and the window closed
+	teams, err := svc.teamsWithHeadroom(ctx, svc.BidLadder(ctx))
+	if err != nil {
+		t.Fatalf("teams: %v", err)
+	}
+	for _, team := range teams {
+		if team.ID == former.ID && (team.RTMCards != 0 || team.Spent != player.BasePrice) {
+			t.Fatalf("former team %+v, want its card used and the price charged", team)
+		}
+	}
+	if _, err := svc.RespondRTM(ctx, nil, true); err != ErrNoRTMWindow {
+		t.Fatalf("answered a closed window: %v", err)
+	}
+}
+
+func TestRetentionsAreChargedToThePurse(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+
+	f := newAuctionFixture(t, root, "Retention League", "")
+	scoped := f.repos
+	teams := NewTeamService(scoped)
+	if err := scoped.Settings.Set(ctx, RetentionRulesKey, map[string]interface{}{
+		"max_retentions": 3, "max_overseas": 1, "max_uncapped": 1, "slabs": []int64{5000, 3000}, "uncapped_price": 1000,
+	}); err != nil {
+		t.Fatalf("set retention_rules: %v", err)
+	}
+
+	team := f.team(&models.Team{Name: "Keepers XI", ShortName: "KXI", Budget: 10_000})
+	var ids []string
+	for i, p := range []struct {
+		country  string
+		uncapped bool
+	}{{"India", false}, {"Australia", false}, {"England", false}, {"India", true}, {"India", true}} {
+		player := f.player(&models.Player{Name: "Keeper " + string(rune('A'+i)), Country: p.country, BasePrice: 2000, Uncapped: p.uncapped})
+		ids = append(ids, player.ID.String())
+	}
+
+	spent := func() int64 {
+		got, err := scoped.Teams.FindByID(ctx, team.ID)
+		if err != nil {
+			t.Fatalf("find team: %v", err)
+		}
+		return got.Spent
+	}
+
+	// Slabs in list order, with the uncapped player at the flat price
+	if err := teams.RetainPlayers(ctx, team.ID, []models.RetainedPlayer{
+		{PlayerID: ids[0]}, {PlayerID: ids[3]}, {PlayerID: ids[1]},
+	}); err != nil {
+		t.Fatalf("retain: %v", err)
+	}
+	if got := spent(); got != 5000+1000+3000 {
+		t.Fatalf("spent %d after retaining, want 9000", got)
+	}
+
+	// A list breaking the rules changes nothing
+	for name, list := range map[string][]models.RetainedPlayer{
+		"too many":     {{PlayerID: ids[0]}, {PlayerID: ids[1]}, {PlayerID: ids[2]}, {PlayerID: ids[3]}},
+		"overseas":     {{PlayerID: ids[1]}, {PlayerID: ids[2]}},
+		"uncapped":     {{PlayerID: ids[3]}, {PlayerID: ids[4]}},
+		"over budget":  {{PlayerID: ids[0]}, {PlayerID: ids[3]}, {PlayerID: ids[1]}},
+		"listed twice": {{PlayerID: ids[0]}, {PlayerID: ids[0]}},
+		"invalid ID":   {{PlayerID: "not-a-uuid"}},
+	} {
+		if name == "over budget" {
+			if err := scoped.Teams.UpdateSpent(ctx, team.ID, 2000); err != nil {
+				t.Fatalf("update spent: %v", err)
+			}
+		}
+		if err := teams.RetainPlayers(ctx, team.ID, list); err == nil {
+			t.Fatalf("%s: retention list accepted", name)
+		}
+		if name == "over budget" {
+			if err := scoped.Teams.UpdateSpent(ctx, team.ID, -2000); err != nil {
+				t.Fatalf("update spent: %v", err)
+			}
+		}
+		if got := spent(); got != 9000 {
+			t.Fatalf("%s: spent %d after a rejected list, want 9000", name, got)
+		}
+	}
+
+	// Submitting a new list refunds the old one
+	if err := teams.RetainPlayers(ctx, team.ID, []models.RetainedPlayer{{PlayerID: ids[2]}}); err != nil {
+		t.Fatalf("retain again: %v", err)
+	}
+	if got := spent(); got != 5000 {
+		t.Fatalf("spent %d after replacing the list, want 5000", got)
+	}
+	retained, err := teams.GetRetainedPlayers(ctx, team.ID)
+	if err != nil || len(retained) != 1 || retained[0].SoldPrice == nil || *retained[0].SoldPrice != 5000 {
+		t.Fatalf("retained %+v (%v), want one player at the first slab", retained, err)
+	}
+}
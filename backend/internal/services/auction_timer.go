@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Lot timer state lives in Redis so it survives restarts and is shared between instances.
+// Each auction has its own keys (see AuctionService.key):
+//   - auction:<id>:timer_deadline  unix ms at which the running countdown expires
+//   - auction:<id>:timer_remaining seconds left while the countdown is paused or not yet started
+//   - auction:<id>:timer_running   whether a countdown is in progress
+//   - auction:<id>:timer_lease     instance that owns ticking and expiry
+const (
+	timerDeadlineKey  = "timer_deadline"
+	timerRemainingKey = "timer_remaining"
+	timerRunningKey   = "timer_running"
+	timerLeaseKey     = "timer_lease"
+
+	// How long a lease holder may go silent before another instance takes over
+	timerLeaseTTL = 3 * time.Second
+	// How often the lease holder checks the deadline
+	timerPollInterval = 200 * time.Millisecond
+)
+
+// acquireLeaseScript takes the lease if it is free or renews it if we already hold it
+var acquireLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+// claimExpiryScript clears the deadline only if it is still the one we saw expire,
+// so a bid that restarted the countdown in the meantime is not lost
+var claimExpiryScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	redis.call('SET', KEYS[2], 0)
+	redis.call('SET', KEYS[3], 'false')
+	return 1
+end
+return 0
+`)
+
+// TimerStatus is a snapshot of the lot countdown
+type TimerStatus struct {
+	Running   bool
+	Remaining int   // whole seconds, rounded up
+	Deadline  int64 // unix ms, 0 when not running
+}
+
+// TimerStatus computes the countdown from the stored deadline
+func (s *AuctionService) TimerStatus(ctx context.Context) TimerStatus {
+	deadline, err := s.redis.Get(ctx, s.key(timerDeadlineKey)).Int64()
+	if err == nil && deadline > 0 {
+		return TimerStatus{
+			Running:   true,
+			Remaining: remainingUntil(deadline, time.Now()),
+			Deadline:  deadline,
+		}
+	}
+	remaining, _ := s.redis.Get(ctx, s.key(timerRemainingKey)).Int()
+	return TimerStatus{Remaining: remaining}
+}
+
+// remainingUntil returns the whole seconds left before deadline (unix ms), rounded up
+func remainingUntil(deadline int64, now time.Time) int {
+	ms := deadline - now.UnixMilli()
+	if ms <= 0 {
+		return 0
+	}
+	return int((ms + 999) / 1000)
+}
+
+// deadlineAfter returns the deadline (unix ms) seconds after now
+func deadlineAfter(now time.Time, seconds int) int64 {
+	return now.Add(time.Duration(seconds) * time.Second).UnixMilli()
+}
+
+// SetTimerCallbacks registers the functions the lot timer calls on every tick and on expiry
+func (s *AuctionService) SetTimerCallbacks(onTick func(remaining int), onExpired func()) {
+	s.timerLock.Lock()
+	defer s.timerLock.Unlock()
+	s.onTimerTick = onTick
+	s.onTimerExpired = onExpired
+}
+
+// startTimer sets a new deadline seconds from now
+func (s *AuctionService) startTimer(ctx context.Context, seconds int) {
+	deadline := deadlineAfter(time.Now(), seconds)
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, s.key(timerDeadlineKey), deadline, 0)
+	pipe.Set(ctx, s.key(timerRemainingKey), seconds, 0)
+	pipe.Set(ctx, s.key(timerRunningKey), true, 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to start lot timer: %v", err)
+	}
+	s.InvalidateState(ctx)
+}
+
+// restartLotTimer resets the countdown to the auction's full duration (or the right-to-match
+// window while one is open) and starts it if the auction is live; otherwise the reset
+// value is kept until the auction resumes
+func (s *AuctionService) restartLotTimer(ctx context.Context, auction *models.Auction) {
+	seconds := auction.TimerDuration
+	if auction.RTMTeamID != nil {
+		seconds = s.rtmWindow(ctx)
+	}
+	if auction.Status != "live" {
+		s.StopTimer()
+		s.redis.Set(ctx, s.key(timerRemainingKey), seconds, 0)
+		return
+	}
+	s.startTimer(ctx, seconds)
+}
+
+// resumeLotTimer continues a paused countdown from the remaining seconds
+func (s *AuctionService) resumeLotTimer(ctx context.Context) {
+	status := s.TimerStatus(ctx)
+	if status.Running {
+		return
+	}
+	s.startTimer(ctx, status.Remaining)
+}
+
+// StopTimer pauses the countdown, keeping the remaining seconds for a later resume
+func (s *AuctionService) StopTimer() {
+	ctx := context.Background()
+	status := s.TimerStatus(ctx)
+
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, s.key(timerDeadlineKey))
+	pipe.Set(ctx, s.key(timerRemainingKey), status.Remaining, 0)
+	pipe.Set(ctx, s.key(timerRunningKey), false, 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to stop lot timer: %v", err)
+	}
+	s.InvalidateState(ctx)
+}
+
+// RunTimer drives the lot countdown until ctx is cancelled. Every instance runs it,
+// but only the holder of the Redis lease broadcasts ticks and fires expiry, so a
+// countdown is never double-counted and is picked up again after a restart.
+func (s *AuctionService) RunTimer(ctx context.Context) {
+	ticker := time.NewTicker(timerPollInterval)
+	defer ticker.Stop()
+
+	lastRemaining := -1
+	var lastDeadline int64
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Hand the lease over straight away rather than waiting for it to lapse
+			if owner, _ := s.redis.Get(context.Background(), s.key(timerLeaseKey)).Result(); owner == s.instanceID {
+				s.redis.Del(context.Background(), s.key(timerLeaseKey))
+			}
+			return
+		case <-ticker.C:
+			func() {
+				// Panic recovery for each tick operation
+				defer func() {
+					if r := recover(); r != nil {
+						log.Printf("Error in timer tick operation: %v", r)
+					}
+				}()
+				s.timerTick(ctx, &lastRemaining, &lastDeadline)
+			}()
+		}
+	}
+}
+
+// timerTick performs one poll of the countdown on behalf of the lease holder
+func (s *AuctionService) timerTick(ctx context.Context, lastRemaining *int, lastDeadline *int64) {
+	held, err := acquireLeaseScript.Run(ctx, s.redis, []string{s.key(timerLeaseKey)},
+		s.instanceID, timerLeaseTTL.Milliseconds()).Int()
+	if err != nil || held == 0 {
+		*lastRemaining = -1
+		return
+	}
+
+	deadlineStr, err := s.redis.Get(ctx, s.key(timerDeadlineKey)).Result()
+	if err != nil {
+		// Countdowns written before deadlines existed only have a running flag and a counter
+		if err == redis.Nil {
+			if running, _ := s.redis.Get(ctx, s.key(timerRunningKey)).Bool(); running {
+				remaining, _ := s.redis.Get(ctx, s.key(timerRemainingKey)).Int()
+				s.startTimer(ctx, remaining)
+			}
+		}
+		*lastRemaining = -1
+		return
+	}
+	deadline, err := strconv.ParseInt(deadlineStr, 10, 64)
+	if err != nil {
+		return
+	}
+
+	s.timerLock.Lock()
+	onTick, onExpired := s.onTimerTick, s.onTimerExpired
+	s.timerLock.Unlock()
+
+	remaining := remainingUntil(deadline, time.Now())
+	if remaining != *lastRemaining || deadline != *lastDeadline {
+		*lastRemaining = remaining
+		*lastDeadline = deadline
+		if onTick != nil {
+			onTick(remaining)
+		}
+	}
+
+	if remaining > 0 {
+		return
+	}
+
+	claimed, err := claimExpiryScript.Run(ctx, s.redis,
+		[]string{s.key(timerDeadlineKey), s.key(timerRemainingKey), s.key(timerRunningKey)}, deadlineStr).Int()
+	if err != nil || claimed == 0 {
+		return
+	}
+	*lastRemaining = -1
+	s.InvalidateState(ctx)
+	if onExpired != nil {
+		onExpired()
+	}
+}
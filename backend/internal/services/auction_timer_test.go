@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemainingUntil(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	tests := []struct {
+		name     string
+		deadline int64
+		want     int
+	}{
+		{"whole seconds", now.UnixMilli() + 15_000, 15},
+		{"part second rounds up", now.UnixMilli() + 14_001, 15},
+		{"just under a second", now.UnixMilli() + 1, 1},
+		{"at the deadline", now.UnixMilli(), 0},
+		{"past the deadline", now.UnixMilli() - 2_500, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingUntil(tt.deadline, now); got != tt.want {
+				t.Fatalf("remainingUntil() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeadlineAfter(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_250)
+	tests := []struct {
+		seconds int
+		elapsed time.Duration // time passed before the countdown is read
+		want    int
+	}{
+		{30, 0, 30},
+		{30, 400 * time.Millisecond, 30}, // a tick mid-second still shows the full second
+		{30, time.Second, 29},
+		{30, 29*time.Second + 999*time.Millisecond, 1},
+		{30, 30 * time.Second, 0},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		deadline := deadlineAfter(now, tt.seconds)
+		if want := now.UnixMilli() + int64(tt.seconds)*1000; deadline != want {
+			t.Fatalf("deadlineAfter(%d) = %d, want %d", tt.seconds, deadline, want)
+		}
+		if got := remainingUntil(deadline, now.Add(tt.elapsed)); got != tt.want {
+			t.Errorf("%ds countdown after %v shows %d, want %d", tt.seconds, tt.elapsed, got, tt.want)
+		}
+	}
+}
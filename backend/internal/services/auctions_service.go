@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrAuctionNotFound is returned for an auction ID that does not exist
+var ErrAuctionNotFound = errors.New("auction not found")
+
+// AuctionsService manages auctions: one per tournament (say a men's league, a women's
+// league and a U-19 league), each with its own teams, players, settings and live state.
+// One bound to an organisation (see Services.ForOrganization) only sees its auctions.
+type AuctionsService struct {
+	repos  *repository.Repositories
+	scopes *auctionScopes
+}
+
+// NewAuctionsService creates a new auctions service
+func NewAuctionsService(repos *repository.Repositories, scopes *auctionScopes) *AuctionsService {
+	return &AuctionsService{repos: repos, scopes: scopes}
+}
+
+// newAuction builds an auction from the settings: the tournament name and season,
+// the lot timer duration and whether lots close on their own
+func newAuction(settings map[string]interface{}, status string) *models.Auction {
+	auction := &models.Auction{
+		Name:          "Auction Session",
+		Season:        "",
+		Status:        status,
+		TimerDuration: 30,
+		Round:         1,
+	}
+	if name, ok := settings["tournament_name"].(string); ok && strings.TrimSpace(name) != "" {
+		auction.Name = name
+	}
+	if season, ok := settings["season"].(string); ok {
+		auction.Season = season
+	}
+	if td, ok := settings["timer_duration"].(float64); ok {
+		auction.TimerDuration = int(td)
+	}
+	auction.AutoClose, _ = settings[AutoCloseKey].(bool)
+	return auction
+}
+
+// List returns every auction, newest first
+func (s *AuctionsService) List(ctx context.Context) ([]models.Auction, error) {
+	return s.repos.Auctions.List(ctx)
+}
+
+// Get returns an auction by ID
+func (s *AuctionsService) Get(ctx context.Context, id uuid.UUID) (*models.Auction, error) {
+	auction, err := s.repos.Auctions.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrAuctionNotFound
+	}
+	return auction, nil
+}
+
+// Create creates a pending auction. Anything not given comes from the settings.
+func (s *AuctionsService) Create(ctx context.Context, req models.CreateAuctionRequest) (*models.Auction, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+	if req.TimerDuration != nil && *req.TimerDuration <= 0 {
+		return nil, errors.New("timer_duration must be a positive number of seconds")
+	}
+
+	settings, _ := s.repos.Settings.GetAll(ctx)
+	auction := newAuction(settings, "pending")
+	auction.Name = strings.TrimSpace(req.Name)
+	auction.OrganizationID = s.repos.OrganizationID()
+	if auction.OrganizationID == nil {
+		orgID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return nil, errors.New("organization_id is required")
+		}
+		if _, err := s.repos.Organizations.FindByID(ctx, orgID); err != nil {
+			return nil, ErrOrganizationNotFound
+		}
+		auction.OrganizationID = &orgID
+	}
+	if req.Season != "" {
+		auction.Season = req.Season
+	}
+	if req.TimerDuration != nil {
+		auction.TimerDuration = *req.TimerDuration
+	}
+	if req.AutoClose != nil {
+		auction.AutoClose = *req.AutoClose
+	}
+
+	if err := s.repos.Auctions.Create(ctx, auction); err != nil {
+		return nil, err
+	}
+	return auction, nil
+}
+
+// Update changes an auction's name, season, timer duration or auto-close mode
+func (s *AuctionsService) Update(ctx context.Context, id uuid.UUID, req models.UpdateAuctionRequest) (*models.Auction, error) {
+	auction, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, errors.New("name is required")
+		}
+		auction.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Season != nil {
+		auction.Season = *req.Season
+	}
+	if req.TimerDuration != nil {
+		if *req.TimerDuration <= 0 {
+			return nil, errors.New("timer_duration must be a positive number of seconds")
+		}
+		auction.TimerDuration = *req.TimerDuration
+	}
+	if req.AutoClose != nil {
+		auction.AutoClose = *req.AutoClose
+	}
+
+	if err := s.repos.Auctions.UpdateDetails(ctx, auction); err != nil {
+		return nil, err
+	}
+	s.scopes.get(id).Auction.InvalidateState(ctx)
+	return auction, nil
+}
+
+// Delete deletes an auction with its teams, players, bids, sales and settings.
+// A live auction must be paused or ended first.
+func (s *AuctionsService) Delete(ctx context.Context, id uuid.UUID) error {
+	auction, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if auction.Status == "live" {
+		return errors.New("pause or end the auction before deleting it")
+	}
+
+	live := s.scopes.get(id).Auction
+	live.StopTimer()
+	if err := s.repos.Auctions.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.scopes.drop(id)
+
+	// Clear Redis state (non-critical, don't fail if this fails)
+	keys := append(live.liveKeys(), live.key(stateVersionKey), live.key(stateSnapshotKey), live.key(timerLeaseKey))
+	live.redis.Del(ctx, keys...)
+	return nil
+}
+
+// OverlayToken returns the token an auction's overlay URLs must carry
+func (s *AuctionsService) OverlayToken(ctx context.Context, id uuid.UUID) (string, error) {
+	token, err := s.repos.Auctions.OverlayToken(ctx, id)
+	if err != nil {
+		return "", ErrAuctionNotFound
+	}
+	return token, nil
+}
+
+// RotateOverlayToken replaces an auction's overlay token, for when an overlay URL leaked
+func (s *AuctionsService) RotateOverlayToken(ctx context.Context, id uuid.UUID) (string, error) {
+	token, err := s.repos.Auctions.RotateOverlayToken(ctx, id)
+	if err != nil {
+		return "", ErrAuctionNotFound
+	}
+	return token, nil
+}
+
+// Default returns the auction used when a request does not name one: the latest not
+// yet completed, else the latest. An organisation without any auction gets one created
+// from the settings.
+func (s *AuctionsService) Default(ctx context.Context) (*models.Auction, error) {
+	auction, err := s.repos.Auctions.FindDefault(ctx)
+	if err == nil {
+		return auction, nil
+	}
+	if s.repos.OrganizationID() == nil {
+		return nil, ErrAuctionNotFound
+	}
+	// Only an organisation without any auction gets one; anything else is a real failure
+	if auctions, listErr := s.repos.Auctions.List(ctx); listErr != nil || len(auctions) > 0 {
+		return nil, err
+	}
+	settings, _ := s.repos.Settings.GetAll(ctx)
+	auction = newAuction(settings, "pending")
+	auction.OrganizationID = s.repos.OrganizationID()
+	if err := s.repos.Auctions.Create(ctx, auction); err != nil {
+		return nil, err
+	}
+	return auction, nil
+}
+
+// Resolve returns the auction a request or connection is for: the one it names (an
+// empty id names none), else the auction of the bidder's team, else the default
+func (s *AuctionsService) Resolve(ctx context.Context, id string, teamID *uuid.UUID) (*models.Auction, error) {
+	if id != "" {
+		auctionID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, ErrAuctionNotFound
+		}
+		return s.Get(ctx, auctionID)
+	}
+	if teamID != nil {
+		if auctionID, err := s.repos.Teams.AuctionOf(ctx, *teamID); err == nil {
+			return s.Get(ctx, auctionID)
+		}
+	}
+	return s.Default(ctx)
+}
@@ -53,7 +53,7 @@ func (s *AuthService) Login(ctx context.Context, req models.LoginRequest) (*mode
 
 	// Generate tokens
 	accessToken, err := utils.GenerateJWT(
-		user.ID, user.Email, user.Role, user.TeamID,
+		user.ID, user.Email, user.Role, user.TeamID, user.OrganizationID,
 		s.cfg.JWTSecret, accessDuration,
 	)
 	if err != nil {
@@ -105,7 +105,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 
 	// Generate new tokens
 	accessToken, err := utils.GenerateJWT(
-		user.ID, user.Email, user.Role, user.TeamID,
+		user.ID, user.Email, user.Role, user.TeamID, user.OrganizationID,
 		s.cfg.JWTSecret, accessDuration,
 	)
 	if err != nil {
@@ -160,6 +160,13 @@ func (s *AuthService) GetCurrentUser(ctx context.Context, userID uuid.UUID) (*mo
 	return user, nil
 }
 
+// IsUserActive reports whether the user still exists and is active. An access token stays
+// valid after its user is suspended or deleted, so long-lived connections check this too.
+func (s *AuthService) IsUserActive(ctx context.Context, userID uuid.UUID) bool {
+	user, err := s.repos.Users.FindByID(ctx, userID)
+	return err == nil && user.Status == "active"
+}
+
 // ChangePassword changes a user's password after verifying the current password
 func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
 	// Get user with password
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/auctionapp/backend/internal/repository"
+)
+
+// BidIncrementsKey is the settings key holding the bid ladder
+const BidIncrementsKey = "bid_increments"
+
+// maxLadderRungs caps how many amounts a rule-based ladder may expand to
+const maxLadderRungs = 1000
+
+// BidLadder is the ordered list of amounts a bid may take
+type BidLadder []int64
+
+// defaultBidLadder is used when the bid_increments setting is missing or invalid
+var defaultBidLadder = BidLadder{
+	2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000,
+	12000, 14000, 16000, 18000, 20000,
+	24000, 28000, 32000, 36000, 40000, 45000, 50000,
+}
+
+// ParseBidLadder builds a ladder from a bid_increments setting value.
+// Two shapes are accepted:
+//   - an explicit list: [2000, 3000, 4000, ...]
+//   - rules: {"start": 2000, "rules": [{"up_to": 10000, "increment": 1000}, {"up_to": 50000, "increment": 2000}]}
+//
+// Rules are applied in order: each one keeps adding its increment while the
+// next amount stays within up_to.
+func ParseBidLadder(value interface{}) (BidLadder, error) {
+	var ladder BidLadder
+
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			amount, ok := toInt64(item)
+			if !ok {
+				return nil, fmt.Errorf("bid_increments[%d] must be a whole number", i)
+			}
+			ladder = append(ladder, amount)
+		}
+
+	case map[string]interface{}:
+		start, ok := toInt64(v["start"])
+		if !ok || start <= 0 {
+			return nil, errors.New("bid_increments.start must be a positive whole number")
+		}
+		rules, ok := v["rules"].([]interface{})
+		if !ok || len(rules) == 0 {
+			return nil, errors.New("bid_increments.rules must be a non-empty list")
+		}
+
+		ladder = BidLadder{start}
+		current := start
+		for i, r := range rules {
+			rule, ok := r.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("bid_increments.rules[%d] must be an object", i)
+			}
+			upTo, ok := toInt64(rule["up_to"])
+			if !ok || upTo <= current {
+				return nil, fmt.Errorf("bid_increments.rules[%d].up_to must be greater than %d", i, current)
+			}
+			increment, ok := toInt64(rule["increment"])
+			if !ok || increment <= 0 {
+				return nil, fmt.Errorf("bid_increments.rules[%d].increment must be a positive whole number", i)
+			}
+			for current+increment <= upTo {
+				current += increment
+				ladder = append(ladder, current)
+				if len(ladder) > maxLadderRungs {
+					return nil, fmt.Errorf("bid_increments expands to more than %d amounts", maxLadderRungs)
+				}
+			}
+		}
+
+	default:
+		return nil, errors.New("bid_increments must be a list of amounts or a rules object")
+	}
+
+	if err := ladder.Validate(); err != nil {
+		return nil, err
+	}
+	return ladder, nil
+}
+
+// Validate checks the ladder is non-empty, positive and strictly increasing
+func (l BidLadder) Validate() error {
+	if len(l) == 0 {
+		return errors.New("bid ladder must contain at least one amount")
+	}
+	if len(l) > maxLadderRungs {
+		return fmt.Errorf("bid ladder cannot contain more than %d amounts", maxLadderRungs)
+	}
+	for i, amount := range l {
+		if amount <= 0 {
+			return fmt.Errorf("bid ladder amount %d must be positive", amount)
+		}
+		if i > 0 && amount <= l[i-1] {
+			return fmt.Errorf("bid ladder must be strictly increasing (%d follows %d)", amount, l[i-1])
+		}
+	}
+	return nil
+}
+
+// Max returns the top of the ladder - the tie-break ceiling
+func (l BidLadder) Max() int64 {
+	return l[len(l)-1]
+}
+
+// Next returns the next valid bid amount given the current bid
+func (l BidLadder) Next(currentBid int64) int64 {
+	if currentBid == 0 {
+		return l[0]
+	}
+	for _, amount := range l {
+		if amount > currentBid {
+			return amount
+		}
+	}
+	// If current bid is at or above max, return max (for tie-bids at the ceiling)
+	return l.Max()
+}
+
+// Contains checks if the amount is in the ladder
+func (l BidLadder) Contains(amount int64) bool {
+	for _, validAmount := range l {
+		if amount == validAmount {
+			return true
+		}
+	}
+	return false
+}
+
+// toInt64 converts a decoded JSON number to a whole int64
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		if n != float64(int64(n)) {
+			return 0, false
+		}
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
+// BidLadder returns the bid ladder configured in settings, falling back to the default
+func (s *AuctionService) BidLadder(ctx context.Context) BidLadder {
+	return loadBidLadder(ctx, s.repos)
+}
+
+// loadBidLadder reads the bid ladder through repos
+func loadBidLadder(ctx context.Context, repos *repository.Repositories) BidLadder {
+	value, err := repos.Settings.Get(ctx, BidIncrementsKey)
+	if err != nil {
+		return defaultBidLadder
+	}
+	ladder, err := ParseBidLadder(value)
+	if err != nil {
+		log.Printf("WARNING: invalid %s setting, using default ladder: %v", BidIncrementsKey, err)
+		return defaultBidLadder
+	}
+	return ladder
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBidLadder(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    BidLadder
+		wantErr bool
+	}{
+		{
+			name:  "list",
+			value: []interface{}{2000.0, 3000.0, 5000.0},
+			want:  BidLadder{2000, 3000, 5000},
+		},
+		{
+			name: "rules",
+			value: map[string]interface{}{
+				"start": 1000.0,
+				"rules": []interface{}{
+					map[string]interface{}{"up_to": 3000.0, "increment": 1000.0},
+					map[string]interface{}{"up_to": 8000.0, "increment": 2500.0},
+				},
+			},
+			want: BidLadder{1000, 2000, 3000, 5500, 8000},
+		},
+		{
+			name: "rule stops short of up_to",
+			value: map[string]interface{}{
+				"start": 1000.0,
+				"rules": []interface{}{map[string]interface{}{"up_to": 4500.0, "increment": 2000.0}},
+			},
+			want: BidLadder{1000, 3000},
+		},
+		{name: "empty list", value: []interface{}{}, wantErr: true},
+		{name: "not a number", value: []interface{}{2000.0, "3000"}, wantErr: true},
+		{name: "fraction", value: []interface{}{2000.5}, wantErr: true},
+		{name: "not increasing", value: []interface{}{2000.0, 2000.0}, wantErr: true},
+		{name: "negative", value: []interface{}{-1000.0, 2000.0}, wantErr: true},
+		{name: "string", value: "2000,3000", wantErr: true},
+		{
+			name:    "missing start",
+			value:   map[string]interface{}{"rules": []interface{}{map[string]interface{}{"up_to": 3000.0, "increment": 1000.0}}},
+			wantErr: true,
+		},
+		{
+			name:    "no rules",
+			value:   map[string]interface{}{"start": 1000.0, "rules": []interface{}{}},
+			wantErr: true,
+		},
+		{
+			name: "up_to below current",
+			value: map[string]interface{}{
+				"start": 5000.0,
+				"rules": []interface{}{map[string]interface{}{"up_to": 4000.0, "increment": 1000.0}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero increment",
+			value: map[string]interface{}{
+				"start": 1000.0,
+				"rules": []interface{}{map[string]interface{}{"up_to": 4000.0, "increment": 0.0}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many rungs",
+			value: map[string]interface{}{
+				"start": 1.0,
+				"rules": []interface{}{map[string]interface{}{"up_to": 10_000.0, "increment": 1.0}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBidLadder(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got ladder %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBidLadderValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ladder  BidLadder
+		wantErr bool
+	}{
+		{"valid", BidLadder{1000, 2000, 5000}, false},
+		{"single amount", BidLadder{1000}, false},
+		{"empty", BidLadder{}, true},
+		{"zero", BidLadder{0, 1000}, true},
+		{"decreasing", BidLadder{2000, 1000}, true},
+		{"repeated", BidLadder{1000, 1000}, true},
+		{"too long", make(BidLadder, maxLadderRungs+1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ladder.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBidLadderNextAndContains(t *testing.T) {
+	ladder := BidLadder{2000, 3000, 5000}
+
+	next := []struct {
+		current, want int64
+	}{
+		{0, 2000},    // no bid yet opens at the first rung
+		{2000, 3000}, // on a rung
+		{2500, 3000}, // between rungs (a base price off the ladder)
+		{1000, 2000}, // below the ladder
+		{5000, 5000}, // at the ceiling ties stay at the top
+		{9000, 5000}, // above the ceiling
+	}
+	for _, tt := range next {
+		if got := ladder.Next(tt.current); got != tt.want {
+			t.Errorf("Next(%d) = %d, want %d", tt.current, got, tt.want)
+		}
+	}
+
+	contains := []struct {
+		amount int64
+		want   bool
+	}{
+		{2000, true},
+		{5000, true},
+		{2500, false},
+		{0, false},
+		{6000, false},
+	}
+	for _, tt := range contains {
+		if got := ladder.Contains(tt.amount); got != tt.want {
+			t.Errorf("Contains(%d) = %v, want %v", tt.amount, got, tt.want)
+		}
+	}
+
+	if got := ladder.Max(); got != 5000 {
+		t.Errorf("Max() = %d, want 5000", got)
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/auctionapp/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// exportTimeFormat is how timestamps appear in exported sheets
+const exportTimeFormat = "2006-01-02 15:04:05"
+
+// ExportService builds the spreadsheets handed out after an auction
+type ExportService struct {
+	repos *repository.Repositories
+}
+
+// NewExportService creates a new export service
+func NewExportService(repos *repository.Repositories) *ExportService {
+	return &ExportService{repos: repos}
+}
+
+// SaleSheet returns every sale: player, team, base vs sold price, bid count and time sold
+func (s *ExportService) SaleSheet(ctx context.Context) (utils.Sheet, error) {
+	sales, err := s.repos.Players.GetSaleSheet(ctx)
+	if err != nil {
+		return utils.Sheet{}, err
+	}
+
+	sheet := utils.Sheet{
+		Name: "Sales",
+		Rows: [][]interface{}{{"Player", "Country", "Role", "Category", "Team", "Team Code", "Base Price", "Sold Price", "Bid Count", "Sold At"}},
+	}
+	for _, sale := range sales {
+		sheet.Rows = append(sheet.Rows, []interface{}{
+			sale.PlayerName, sale.Country, sale.Role, sale.Category, sale.TeamName, sale.TeamShort,
+			sale.BasePrice, sale.SoldPrice, sale.BidCount, formatExportTime(sale.SoldAt),
+		})
+	}
+	return sheet, nil
+}
+
+// squadExport is one team and its player rows
+type squadExport struct {
+	team models.Team
+	rows [][]interface{}
+}
+
+// squadColumns heads each player row of a squad export
+var squadColumns = []interface{}{"Player", "Country", "Role", "Category", "Overseas", "Acquired", "Base Price", "Price", "Badge"}
+
+// squads loads every team (or just teamID) with its sold and retained players
+func (s *ExportService) squads(ctx context.Context, teamID *uuid.UUID) ([]squadExport, error) {
+	teams, err := s.repos.Teams.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home := loadHomeCountry(ctx, s.repos)
+
+	var squads []squadExport
+	for _, team := range teams {
+		if teamID != nil && team.ID != *teamID {
+			continue
+		}
+		players, err := s.repos.Players.FindByTeamID(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		squad := squadExport{team: team}
+		for i := range players {
+			p := &players[i]
+			overseas := "No"
+			if isOverseas(p, home) {
+				overseas = "Yes"
+			}
+			acquired := "Auction"
+			if p.Status == "retained" {
+				acquired = "Retained"
+			}
+			var price, badge interface{}
+			if p.SoldPrice != nil {
+				price = *p.SoldPrice
+			}
+			if p.Badge != nil {
+				badge = *p.Badge
+			}
+			squad.rows = append(squad.rows, []interface{}{
+				p.Name, p.Country, p.Role, p.Category, overseas, acquired, p.BasePrice, price, badge,
+			})
+		}
+		squads = append(squads, squad)
+	}
+
+	if teamID != nil && len(squads) == 0 {
+		return nil, errors.New("team not found")
+	}
+	return squads, nil
+}
+
+// SquadSheets returns a summary sheet of every team's purse followed by one sheet per team
+// ending with its remaining purse. When teamID is set only that team's sheet is returned.
+func (s *ExportService) SquadSheets(ctx context.Context, teamID *uuid.UUID) ([]utils.Sheet, error) {
+	squads, err := s.squads(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := utils.Sheet{
+		Name: "Summary",
+		Rows: [][]interface{}{{"Team", "Team Code", "Budget", "Spent", "Remaining Purse", "Players", "Overseas", "Max Players", "Max Overseas"}},
+	}
+	var sheets []utils.Sheet
+	for _, squad := range squads {
+		team := squad.team
+		remaining := team.Budget - team.Spent
+		summary.Rows = append(summary.Rows, []interface{}{
+			team.Name, team.ShortName, team.Budget, team.Spent, remaining,
+			team.PlayerCount, team.ForeignCount, team.MaxPlayers, team.MaxForeign,
+		})
+
+		sheet := utils.Sheet{Name: team.ShortName, Rows: [][]interface{}{squadColumns}}
+		sheet.Rows = append(sheet.Rows, squad.rows...)
+		sheet.Rows = append(sheet.Rows,
+			[]interface{}{},
+			[]interface{}{"Budget", team.Budget},
+			[]interface{}{"Spent", team.Spent},
+			[]interface{}{"Remaining Purse", remaining},
+		)
+		sheets = append(sheets, sheet)
+	}
+
+	if teamID != nil {
+		return sheets, nil
+	}
+	return append([]utils.Sheet{summary}, sheets...), nil
+}
+
+// SquadCSVSheet flattens the squads into one sheet (CSV has no worksheets), one row per player
+func (s *ExportService) SquadCSVSheet(ctx context.Context, teamID *uuid.UUID) (utils.Sheet, error) {
+	squads, err := s.squads(ctx, teamID)
+	if err != nil {
+		return utils.Sheet{}, err
+	}
+
+	header := append([]interface{}{"Team", "Team Code", "Remaining Purse"}, squadColumns...)
+	flat := utils.Sheet{Name: "Squads", Rows: [][]interface{}{header}}
+	for _, squad := range squads {
+		team := squad.team
+		for _, row := range squad.rows {
+			flat.Rows = append(flat.Rows, append([]interface{}{team.Name, team.ShortName, team.Budget - team.Spent}, row...))
+		}
+	}
+	return flat, nil
+}
+
+// UnsoldSheet returns the players that went unsold
+func (s *ExportService) UnsoldSheet(ctx context.Context) (utils.Sheet, error) {
+	players, err := s.repos.Players.FindByStatus(ctx, "unsold")
+	if err != nil {
+		return utils.Sheet{}, err
+	}
+
+	sheet := utils.Sheet{
+		Name: "Unsold",
+		Rows: [][]interface{}{{"Player", "Country", "Role", "Category", "Base Price"}},
+	}
+	for _, p := range players {
+		sheet.Rows = append(sheet.Rows, []interface{}{p.Name, p.Country, p.Role, p.Category, p.BasePrice})
+	}
+	return sheet, nil
+}
+
+func formatExportTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(exportTimeFormat)
+}
+
+// ResultsWorkbook returns every export in one workbook: sales, squad summary, team squads and unsold
+func (s *ExportService) ResultsWorkbook(ctx context.Context) ([]utils.Sheet, error) {
+	sales, err := s.SaleSheet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	squads, err := s.SquadSheets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	unsold, err := s.UnsoldSheet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := append([]utils.Sheet{sales}, squads...)
+	return append(sheets, unsold), nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrOrganizationNotFound is returned for an organisation ID that does not exist
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// slugPattern is the form of an organisation slug: lowercase words joined by hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// OrganizationService manages organisations: independent leagues whose users, auctions,
+// teams and players are invisible to each other
+type OrganizationService struct {
+	repos    *repository.Repositories
+	auctions *AuctionsService
+}
+
+// NewOrganizationService creates a new organisation service
+func NewOrganizationService(repos *repository.Repositories, auctions *AuctionsService) *OrganizationService {
+	return &OrganizationService{repos: repos, auctions: auctions}
+}
+
+// List returns every organisation
+func (s *OrganizationService) List(ctx context.Context) ([]models.Organization, error) {
+	return s.repos.Organizations.FindAll(ctx)
+}
+
+// Get returns an organisation by ID
+func (s *OrganizationService) Get(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	org, err := s.repos.Organizations.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// Create creates an organisation
+func (s *OrganizationService) Create(ctx context.Context, req models.CreateOrganizationRequest) (*models.Organization, error) {
+	org := &models.Organization{Name: strings.TrimSpace(req.Name), Slug: strings.ToLower(strings.TrimSpace(req.Slug))}
+	if err := validateOrganization(org); err != nil {
+		return nil, err
+	}
+	if err := s.repos.Organizations.Create(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Update changes an organisation's name or slug
+func (s *OrganizationService) Update(ctx context.Context, id uuid.UUID, req models.UpdateOrganizationRequest) (*models.Organization, error) {
+	org, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		org.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Slug != nil {
+		org.Slug = strings.ToLower(strings.TrimSpace(*req.Slug))
+	}
+	if err := validateOrganization(org); err != nil {
+		return nil, err
+	}
+	if err := s.repos.Organizations.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Delete deletes an organisation with its users and auctions (and so their teams,
+// players, bids, sales and settings). Live auctions must be paused or ended first.
+func (s *OrganizationService) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	// Deleting each auction through the auctions service also stops its timer and
+	// clears its Redis state
+	auctions := NewAuctionsService(s.repos.ForOrganization(id), s.auctions.scopes)
+	list, err := auctions.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, auction := range list {
+		if auction.Status == "live" {
+			return errors.New("pause or end the organization's live auctions before deleting it")
+		}
+	}
+	for _, auction := range list {
+		if err := auctions.Delete(ctx, auction.ID); err != nil {
+			return err
+		}
+	}
+	return s.repos.Organizations.Delete(ctx, id)
+}
+
+// validateOrganization checks an organisation's name and slug
+func validateOrganization(org *models.Organization) error {
+	if org.Name == "" {
+		return errors.New("name is required")
+	}
+	if !slugPattern.MatchString(org.Slug) {
+		return errors.New("slug must be lowercase letters, digits and hyphens")
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// overlayTickerSize is how many recent bids the ticker shows
+const overlayTickerSize = 5
+
+// OverlayService turns the live auction state into broadcast graphics payloads
+type OverlayService struct {
+	auction *AuctionService
+}
+
+// NewOverlayService creates a new overlay service
+func NewOverlayService(auction *AuctionService) *OverlayService {
+	return &OverlayService{auction: auction}
+}
+
+// Frame returns every graphic, built from the materialised auction state
+func (s *OverlayService) Frame(ctx context.Context) (*models.OverlayFrame, error) {
+	state, err := s.auction.CachedState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OverlayFrame{
+		Status: state.Status,
+		Lot:    lotCard(state),
+		Bids:   bidTicker(state),
+		Purses: purseTable(state),
+		NextUp: nextUp(state),
+	}, nil
+}
+
+// lotCard returns the current lot card, or nil when no player is on the block
+func lotCard(state *models.AuctionState) *models.OverlayLotCard {
+	if state.CurrentPlayer == nil {
+		return nil
+	}
+	card := &models.OverlayLotCard{
+		Player:        overlayPlayer(state.CurrentPlayer),
+		BidCount:      len(state.BidHistory),
+		TimerRunning:  state.TimerRunning,
+		TimerDeadline: state.TimerDeadline,
+	}
+	if state.CurrentBid != nil {
+		card.CurrentBid = *state.CurrentBid
+	} else {
+		card.CurrentBid = state.CurrentPlayer.BasePrice
+	}
+	card.CurrentBidDisplay = formatAmount(card.CurrentBid)
+	if state.CurrentBidder != nil {
+		team := overlayTeam(state.CurrentBidder)
+		card.Leader = &team
+	}
+	return card
+}
+
+// bidTicker returns the latest bids on the current lot, newest first
+func bidTicker(state *models.AuctionState) []models.OverlayBid {
+	// Bid rows only carry the team's name and colour; logos come from the team list
+	teams := make(map[uuid.UUID]*models.Team, len(state.Teams))
+	for i := range state.Teams {
+		teams[state.Teams[i].ID] = &state.Teams[i]
+	}
+
+	ticker := []models.OverlayBid{}
+	for _, bid := range state.BidHistory {
+		if bid.Voided {
+			continue
+		}
+		team := teams[bid.TeamID]
+		if team == nil {
+			team = bid.Team
+		}
+		if team == nil {
+			continue
+		}
+		item := overlayBid(&bid)
+		item.Team = overlayTeam(team)
+		ticker = append(ticker, item)
+		if len(ticker) == overlayTickerSize {
+			break
+		}
+	}
+	return ticker
+}
+
+// purseTable returns every team's remaining purse and squad size
+func purseTable(state *models.AuctionState) []models.OverlayPurse {
+	purses := make([]models.OverlayPurse, 0, len(state.Teams))
+	for i := range state.Teams {
+		team := &state.Teams[i]
+		remaining := team.Budget - team.Spent
+		purses = append(purses, models.OverlayPurse{
+			Team:             overlayTeam(team),
+			Remaining:        remaining,
+			RemainingDisplay: formatAmount(remaining),
+			MaxBid:           team.MaxBid,
+			MaxBidDisplay:    formatAmount(team.MaxBid),
+			Players:          team.PlayerCount,
+			MaxPlayers:       team.MaxPlayers,
+		})
+	}
+	return purses
+}
+
+// nextUp returns the players queued after the current lot
+func nextUp(state *models.AuctionState) []models.OverlayPlayer {
+	next := make([]models.OverlayPlayer, 0, len(state.QueueNext))
+	for i := range state.QueueNext {
+		next = append(next, overlayPlayer(&state.QueueNext[i]))
+	}
+	return next
+}
+
+// SoldCard returns the "SOLD" graphic for a completed sale
+func (s *OverlayService) SoldCard(player *models.Player, team *models.Team) *models.OverlaySoldCard {
+	card := &models.OverlaySoldCard{
+		Player: overlayPlayer(player),
+		Team:   overlayTeam(team),
+	}
+	if player.SoldPrice != nil {
+		card.Amount = *player.SoldPrice
+	}
+	card.AmountDisplay = formatAmount(card.Amount)
+	return card
+}
+
+// BidItem returns an accepted bid as a bid ticker line
+func (s *OverlayService) BidItem(bid *models.Bid) models.OverlayBid {
+	return overlayBid(bid)
+}
+
+// overlayBid returns a bid as a ticker line
+func overlayBid(bid *models.Bid) models.OverlayBid {
+	item := models.OverlayBid{
+		Amount:        bid.Amount,
+		AmountDisplay: formatAmount(bid.Amount),
+		BidTime:       bid.BidTime,
+	}
+	if bid.Team != nil {
+		item.Team = overlayTeam(bid.Team)
+		item.Team.ID = bid.TeamID
+	}
+	return item
+}
+
+// overlayTeam returns the parts of a team a graphic draws
+func overlayTeam(team *models.Team) models.OverlayTeam {
+	return models.OverlayTeam{
+		ID:        team.ID,
+		Name:      team.Name,
+		ShortName: team.ShortName,
+		Color:     team.Color,
+		LogoURL:   team.LogoURL,
+	}
+}
+
+// overlayPlayer returns the parts of a player a graphic draws
+func overlayPlayer(player *models.Player) models.OverlayPlayer {
+	return models.OverlayPlayer{
+		ID:               player.ID,
+		Name:             player.Name,
+		Country:          player.Country,
+		CountryFlag:      player.CountryFlag,
+		Role:             player.Role,
+		Category:         player.Category,
+		ImageURL:         player.ImageURL,
+		BasePrice:        player.BasePrice,
+		BasePriceDisplay: formatAmount(player.BasePrice),
+	}
+}
+
+// formatAmount renders rupees the way the auction screens do: ₹1.5Cr, ₹75.0L, ₹50,000
+func formatAmount(amount int64) string {
+	switch {
+	case amount >= 10_000_000:
+		return "₹" + strconv.FormatFloat(float64(amount)/10_000_000, 'f', 1, 64) + "Cr"
+	case amount >= 100_000:
+		return "₹" + strconv.FormatFloat(float64(amount)/100_000, 'f', 1, 64) + "L"
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if amount < 0 {
+		return "₹" + digits
+	}
+	// Group thousands as the en-US locale does
+	out := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return "₹" + string(out)
+}
@@ -0,0 +1,470 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/auctionapp/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// maxImportRows caps how many players a single file may contain
+const maxImportRows = 5000
+
+// importFields are the player fields a column can be mapped to
+var importFields = []string{"name", "country", "country_flag", "role", "base_price", "category", "image_url", "queue_order", "previous_team", "uncapped"}
+
+// importFieldAliases are the header names recognised for each field when no mapping is given.
+// Headers are compared after normalizeHeader.
+var importFieldAliases = map[string][]string{
+	"name":          {"name", "playername", "player", "fullname"},
+	"country":       {"country", "nationality", "nation"},
+	"country_flag":  {"countryflag", "flag"},
+	"role":          {"role", "playingrole", "skill"},
+	"base_price":    {"baseprice", "base", "price", "reserveprice"},
+	"category":      {"category", "set", "pool"},
+	"image_url":     {"imageurl", "image", "photo", "photourl", "picture"},
+	"queue_order":   {"queueorder", "order", "queue", "lot", "lotno", "lotnumber"},
+	"previous_team": {"previousteam", "prevteam", "formerteam", "lastteam", "rtmteam"},
+	"uncapped":      {"uncapped"},
+}
+
+// importRoles maps normalised role spellings to the roles the players table accepts
+var importRoles = map[string]string{
+	"batsman":      "Batsman",
+	"batter":       "Batsman",
+	"bat":          "Batsman",
+	"bowler":       "Bowler",
+	"bowl":         "Bowler",
+	"allrounder":   "All-rounder",
+	"ar":           "All-rounder",
+	"wicketkeeper": "Wicketkeeper",
+	"keeper":       "Wicketkeeper",
+	"wk":           "Wicketkeeper",
+	"wkbatsman":    "Wicketkeeper",
+	"wkbatter":     "Wicketkeeper",
+}
+
+// importFlags maps normalised yes/no spellings to their value
+var importFlags = map[string]bool{
+	"yes": true, "y": true, "true": true, "1": true,
+	"no": false, "n": false, "false": false, "0": false,
+}
+
+// errImportInvalid rolls back an import whose rows failed validation inside the transaction
+var errImportInvalid = errors.New("import has invalid rows")
+
+// PlayerImportOptions controls how an uploaded file is read
+type PlayerImportOptions struct {
+	DryRun bool
+	// Mapping maps a player field (e.g. "base_price") to the header of the column holding it.
+	// Fields left out are matched by their usual header names.
+	Mapping map[string]string
+	// StatsColumns lists the headers stored in stats. When empty every unmapped column is used.
+	StatsColumns []string
+}
+
+// importRow is a non-blank data row and its spreadsheet row number
+type importRow struct {
+	num   int
+	cells []string
+}
+
+// importColumns is the resolved header layout of a file
+type importColumns struct {
+	fields map[string]int // field -> column index
+	stats  map[string]int // stats key -> column index
+}
+
+// Import reads players from a CSV or XLSX file. Every row is validated and checked for
+// duplicates against existing players and the rest of the file; nothing is written unless
+// every row is valid. With DryRun set the rows are only validated.
+func (s *PlayerService) Import(ctx context.Context, filename string, r io.Reader, opts PlayerImportOptions) (*models.PlayerImportResult, error) {
+	rows, err := utils.ReadSpreadsheet(filename, r)
+	if err != nil {
+		return nil, err
+	}
+	// The header is the first non-blank row; row numbers in errors match the spreadsheet
+	headerIdx := 0
+	for headerIdx < len(rows) && isBlankRow(rows[headerIdx]) {
+		headerIdx++
+	}
+	if headerIdx == len(rows) {
+		return nil, errors.New("file is empty")
+	}
+	header := rows[headerIdx]
+	data := make([]importRow, 0, len(rows)-headerIdx-1)
+	for i := headerIdx + 1; i < len(rows); i++ {
+		if !isBlankRow(rows[i]) {
+			data = append(data, importRow{num: i + 1, cells: rows[i]})
+		}
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file must have a header row and at least one player")
+	}
+	if len(data) > maxImportRows {
+		return nil, fmt.Errorf("file has %d players - the limit is %d per import", len(data), maxImportRows)
+	}
+
+	cols, err := resolveImportColumns(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PlayerImportResult{
+		DryRun:       opts.DryRun,
+		TotalRows:    len(data),
+		Mapping:      make(map[string]string),
+		StatsColumns: make([]string, 0, len(cols.stats)),
+		Errors:       []models.ImportRowError{},
+	}
+	for field, idx := range cols.fields {
+		result.Mapping[field] = strings.TrimSpace(header[idx])
+	}
+	for key := range cols.stats {
+		result.StatsColumns = append(result.StatsColumns, key)
+	}
+	sort.Strings(result.StatsColumns)
+
+	if opts.DryRun {
+		result.Players, result.Errors, err = s.buildImportPlayers(ctx, s.repos, data, cols)
+		if err != nil {
+			return nil, err
+		}
+		result.ValidRows = len(result.Players)
+		return result, nil
+	}
+
+	// Validate again inside the transaction, holding the roster lock so a concurrent
+	// import cannot add the same players or queue positions between the checks and inserts
+	err = s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Players.LockRoster(ctx); err != nil {
+			return err
+		}
+		players, rowErrors, err := s.buildImportPlayers(ctx, tx, data, cols)
+		if err != nil {
+			return err
+		}
+		result.Players, result.Errors = players, rowErrors
+		result.ValidRows = len(players)
+		if len(rowErrors) > 0 {
+			return errImportInvalid
+		}
+
+		for i := range players {
+			if err := tx.Players.Create(ctx, &players[i]); err != nil {
+				return fmt.Errorf("failed to import %s: %w", players[i].Name, err)
+			}
+		}
+		result.Imported = len(players)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportInvalid) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// buildImportPlayers turns data rows into players, collecting every validation error.
+// Players that fail validation are left out of the returned list.
+func (s *PlayerService) buildImportPlayers(ctx context.Context, repos *repository.Repositories, data []importRow, cols importColumns) ([]models.Player, []models.ImportRowError, error) {
+	existing, err := repos.Players.FindIdentityKeys(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	nextOrder, err := repos.Players.MaxQueueOrder(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Previous teams are given by name or short name
+	teams, err := repos.Teams.FindAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	teamIDs := make(map[string]uuid.UUID, 2*len(teams))
+	for _, team := range teams {
+		teamIDs[strings.ToLower(team.Name)] = team.ID
+		teamIDs[strings.ToLower(team.ShortName)] = team.ID
+	}
+
+	players := make([]models.Player, 0, len(data))
+	rowErrors := []models.ImportRowError{}
+	seen := make(map[string]int) // identity key -> first row number in this file
+
+	for _, row := range data {
+		rowNum := row.num
+		player, errs := parseImportRow(row.cells, cols, rowNum)
+
+		if idx, ok := cols.fields["previous_team"]; ok && idx < len(row.cells) {
+			if name := strings.TrimSpace(row.cells[idx]); name != "" {
+				if id, ok := teamIDs[strings.ToLower(name)]; ok {
+					player.PreviousTeamID = &id
+				} else {
+					errs = append(errs, models.ImportRowError{Row: rowNum, Field: "previous_team", Message: fmt.Sprintf("unknown team %q", name)})
+				}
+			}
+		}
+
+		if player.Name != "" && player.Country != "" {
+			key := strings.ToLower(strings.TrimSpace(player.Name)) + "|" + strings.ToLower(strings.TrimSpace(player.Country))
+			if _, ok := existing[key]; ok {
+				errs = append(errs, models.ImportRowError{Row: rowNum, Field: "name", Message: fmt.Sprintf("%s (%s) already exists", player.Name, player.Country)})
+			} else if first, ok := seen[key]; ok {
+				errs = append(errs, models.ImportRowError{Row: rowNum, Field: "name", Message: fmt.Sprintf("duplicate of row %d", first)})
+			} else {
+				seen[key] = rowNum
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		// Rows without a queue order join the end of the queue in file order
+		if player.QueueOrder == nil {
+			nextOrder++
+			order := nextOrder
+			player.QueueOrder = &order
+		} else if *player.QueueOrder > nextOrder {
+			nextOrder = *player.QueueOrder
+		}
+		player.Status = "available"
+		players = append(players, player)
+	}
+	return players, rowErrors, nil
+}
+
+// parseImportRow validates one data row
+func parseImportRow(row []string, cols importColumns, rowNum int) (models.Player, []models.ImportRowError) {
+	var errs []models.ImportRowError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, models.ImportRowError{Row: rowNum, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	cell := func(field string) string {
+		idx, ok := cols.fields[field]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	player := models.Player{
+		Name:        cell("name"),
+		Country:     cell("country"),
+		CountryFlag: cell("country_flag"),
+		Category:    cell("category"),
+		Stats:       make(map[string]interface{}),
+	}
+	if player.Name == "" {
+		fail("name", "name is required")
+	}
+	if player.Country == "" {
+		fail("country", "country is required")
+	}
+	if player.Category == "" {
+		player.Category = "Set 1"
+	}
+	if len(player.Name) > 255 {
+		fail("name", "name is longer than 255 characters")
+	}
+	if len(player.Country) > 100 {
+		fail("country", "country is longer than 100 characters")
+	}
+	if len(player.CountryFlag) > 10 {
+		fail("country_flag", "country flag is longer than 10 characters")
+	}
+	if len(player.Category) > 50 {
+		fail("category", "category is longer than 50 characters")
+	}
+
+	if role := cell("role"); role == "" {
+		fail("role", "role is required")
+	} else if normalized, ok := importRoles[normalizeHeader(role)]; ok {
+		player.Role = normalized
+	} else {
+		fail("role", "unknown role %q - use Batsman, Bowler, All-rounder or Wicketkeeper", role)
+	}
+
+	if price := cell("base_price"); price == "" {
+		fail("base_price", "base price is required")
+	} else if amount, ok := parseImportNumber(price); !ok || amount <= 0 {
+		fail("base_price", "base price %q must be a positive whole number", price)
+	} else {
+		player.BasePrice = amount
+	}
+
+	if order := cell("queue_order"); order != "" {
+		if n, ok := parseImportNumber(order); !ok || n < 0 || n > 1_000_000 {
+			fail("queue_order", "queue order %q must be a non-negative whole number", order)
+		} else {
+			queueOrder := int(n)
+			player.QueueOrder = &queueOrder
+		}
+	}
+
+	if flag := cell("uncapped"); flag != "" {
+		if uncapped, ok := importFlags[normalizeHeader(flag)]; ok {
+			player.Uncapped = uncapped
+		} else {
+			fail("uncapped", "uncapped %q must be yes or no", flag)
+		}
+	}
+
+	if url := cell("image_url"); url != "" {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "/") {
+			fail("image_url", "image URL must start with http://, https:// or /")
+		} else if len(url) > 500 {
+			fail("image_url", "image URL is longer than 500 characters")
+		} else {
+			player.ImageURL = &url
+		}
+	}
+
+	for key, idx := range cols.stats {
+		if idx >= len(row) {
+			continue
+		}
+		value := strings.TrimSpace(row[idx])
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			player.Stats[key] = n
+		} else {
+			player.Stats[key] = value
+		}
+	}
+
+	return player, errs
+}
+
+// resolveImportColumns works out which column holds each field
+func resolveImportColumns(header []string, opts PlayerImportOptions) (importColumns, error) {
+	cols := importColumns{fields: make(map[string]int), stats: make(map[string]int)}
+
+	byName := make(map[string]int)
+	for i, h := range header {
+		key := normalizeHeader(h)
+		if key == "" {
+			continue
+		}
+		if _, dup := byName[key]; dup {
+			return cols, fmt.Errorf("column %q appears more than once", strings.TrimSpace(h))
+		}
+		byName[key] = i
+	}
+
+	used := make(map[int]bool)
+	for field, column := range opts.Mapping {
+		if !isImportField(field) {
+			return cols, fmt.Errorf("unknown field %q in mapping", field)
+		}
+		idx, ok := byName[normalizeHeader(column)]
+		if !ok {
+			return cols, fmt.Errorf("mapped column %q for %s is not in the file", column, field)
+		}
+		if used[idx] {
+			return cols, fmt.Errorf("column %q is mapped to more than one field", column)
+		}
+		cols.fields[field] = idx
+		used[idx] = true
+	}
+
+	for _, field := range importFields {
+		if _, ok := cols.fields[field]; ok {
+			continue
+		}
+		for _, alias := range importFieldAliases[field] {
+			if idx, ok := byName[alias]; ok && !used[idx] {
+				cols.fields[field] = idx
+				used[idx] = true
+				break
+			}
+		}
+	}
+
+	for _, field := range []string{"name", "country", "role", "base_price"} {
+		if _, ok := cols.fields[field]; !ok {
+			return cols, fmt.Errorf("no column found for %s - add a mapping for it", field)
+		}
+	}
+
+	if len(opts.StatsColumns) > 0 {
+		for _, column := range opts.StatsColumns {
+			idx, ok := byName[normalizeHeader(column)]
+			if !ok {
+				return cols, fmt.Errorf("stats column %q is not in the file", column)
+			}
+			if used[idx] {
+				return cols, fmt.Errorf("stats column %q is already mapped to a player field", column)
+			}
+			cols.stats[statsKey(header[idx])] = idx
+		}
+		return cols, nil
+	}
+
+	// Free-form stats: every column not mapped to a field
+	for i, h := range header {
+		if !used[i] && normalizeHeader(h) != "" {
+			cols.stats[statsKey(h)] = i
+		}
+	}
+	return cols, nil
+}
+
+// normalizeHeader lower-cases a header and strips everything but letters and digits
+func normalizeHeader(h string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(h) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// statsKey turns a column header into a stats key, e.g. "Strike Rate" -> "strike_rate"
+func statsKey(h string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.TrimSpace(h))), "_")
+}
+
+// parseImportNumber parses a whole number, allowing thousands separators ("2,00,000")
+func parseImportNumber(value string) (int64, bool) {
+	value = strings.ReplaceAll(strings.ReplaceAll(value, ",", ""), " ", "")
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, true
+	}
+	// Spreadsheets often store whole numbers as "2000.0"
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != float64(int64(f)) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+func isImportField(field string) bool {
+	for _, f := range importFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlankRow reports whether every cell in a row is empty
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNormalizeHeader(t *testing.T) {
+	tests := []struct {
+		header, want string
+	}{
+		{"Name", "name"},
+		{"  Base Price (₹) ", "baseprice"},
+		{"base_price", "baseprice"},
+		{"Lot No.", "lotno"},
+		{"T20 Matches", "t20matches"},
+		{"---", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeHeader(tt.header); got != tt.want {
+			t.Errorf("normalizeHeader(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestParseImportNumber(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   int64
+		wantOK bool
+	}{
+		{"2000", 2000, true},
+		{"2,00,000", 200000, true}, // Indian grouping
+		{"200,000", 200000, true},
+		{"2 000", 2000, true},
+		{"2000.0", 2000, true}, // whole number stored as a float
+		{"-5", -5, true},       // callers reject negatives
+		{"2000.5", 0, false},
+		{"2k", 0, false},
+		{"", 0, false},
+		{"NaN", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseImportNumber(tt.value)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseImportNumber(%q) = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestResolveImportColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    []string
+		opts      PlayerImportOptions
+		wantCols  map[string]int
+		wantStats map[string]int
+		wantErr   bool
+	}{
+		{
+			name:      "aliases",
+			header:    []string{"Player Name", "Nationality", "Playing Role", "Reserve Price", "Lot No", "Matches"},
+			wantCols:  map[string]int{"name": 0, "country": 1, "role": 2, "base_price": 3, "queue_order": 4},
+			wantStats: map[string]int{"matches": 5},
+		},
+		{
+			name:      "mapping wins over aliases",
+			header:    []string{"Name", "Country", "Role", "Price", "Auction Price"},
+			opts:      PlayerImportOptions{Mapping: map[string]string{"base_price": "Auction Price"}},
+			wantCols:  map[string]int{"name": 0, "country": 1, "role": 2, "base_price": 4},
+			wantStats: map[string]int{"price": 3},
+		},
+		{
+			name:      "chosen stats columns",
+			header:    []string{"Name", "Country", "Role", "Base Price", "Strike Rate", "Notes"},
+			opts:      PlayerImportOptions{StatsColumns: []string{"strike rate"}},
+			wantCols:  map[string]int{"name": 0, "country": 1, "role": 2, "base_price": 3},
+			wantStats: map[string]int{"strike_rate": 4},
+		},
+		{
+			name:    "duplicate headers",
+			header:  []string{"Name", "Country", "Role", "Base Price", "base_price"},
+			wantErr: true,
+		},
+		{
+			name:    "missing required column",
+			header:  []string{"Name", "Country", "Role"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mapped field",
+			header:  []string{"Name", "Country", "Role", "Base Price"},
+			opts:    PlayerImportOptions{Mapping: map[string]string{"salary": "Base Price"}},
+			wantErr: true,
+		},
+		{
+			name:    "mapped column missing",
+			header:  []string{"Name", "Country", "Role", "Base Price"},
+			opts:    PlayerImportOptions{Mapping: map[string]string{"base_price": "Price"}},
+			wantErr: true,
+		},
+		{
+			name:    "column mapped twice",
+			header:  []string{"Name", "Country", "Role", "Base Price"},
+			opts:    PlayerImportOptions{Mapping: map[string]string{"name": "Name", "country": "Name"}},
+			wantErr: true,
+		},
+		{
+			name:    "stats column already a field",
+			header:  []string{"Name", "Country", "Role", "Base Price"},
+			opts:    PlayerImportOptions{StatsColumns: []string{"Role"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, err := resolveImportColumns(tt.header, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got columns %v, want an error", cols.fields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(cols.fields, tt.wantCols) {
+				t.Errorf("fields = %v, want %v", cols.fields, tt.wantCols)
+			}
+			if !reflect.DeepEqual(cols.stats, tt.wantStats) {
+				t.Errorf("stats = %v, want %v", cols.stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	header := []string{"Name", "Country", "Role", "Base Price", "Queue Order", "Image", "Runs"}
+	cols, err := resolveImportColumns(header, PlayerImportOptions{})
+	if err != nil {
+		t.Fatalf("resolve columns: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		row        []string
+		wantRole   string
+		wantPrice  int64
+		wantFields []string // fields with errors, in order
+	}{
+		{
+			name:      "valid",
+			row:       []string{"Virat", "India", "batter", "2,00,000", "3", "https://img/1.png", "5000"},
+			wantRole:  "Batsman",
+			wantPrice: 200000,
+		},
+		{
+			name:      "float price and role spelling",
+			row:       []string{"Rashid", "Afghanistan", "All Rounder", "2000.0", "", "", ""},
+			wantRole:  "All-rounder",
+			wantPrice: 2000,
+		},
+		{
+			name:      "short row",
+			row:       []string{"Rishabh", "India", "WK", "1000"},
+			wantRole:  "Wicketkeeper",
+			wantPrice: 1000,
+		},
+		{
+			name:       "unknown role",
+			row:        []string{"Someone", "India", "Captain", "1000", "", "", ""},
+			wantPrice:  1000,
+			wantFields: []string{"role"},
+		},
+		{
+			name:       "every field wrong",
+			row:        []string{"", "", "", "1000.5", "-1", "ftp://img", ""},
+			wantFields: []string{"name", "country", "role", "base_price", "queue_order", "image_url"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, errs := parseImportRow(tt.row, cols, 7)
+			var fields []string
+			for _, e := range errs {
+				if e.Row != 7 {
+					t.Errorf("error on row %d, want 7", e.Row)
+				}
+				fields = append(fields, e.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("errors on %v, want %v", fields, tt.wantFields)
+			}
+			if player.Role != tt.wantRole || player.BasePrice != tt.wantPrice {
+				t.Errorf("got role %q price %d, want %q %d", player.Role, player.BasePrice, tt.wantRole, tt.wantPrice)
+			}
+			if player.Category != "Set 1" {
+				t.Errorf("category = %q, want the default Set 1", player.Category)
+			}
+		})
+	}
+
+	player, _ := parseImportRow([]string{"Virat", "India", "Batsman", "1000", "3", "", "5000"}, cols, 2)
+	if player.QueueOrder == nil || *player.QueueOrder != 3 {
+		t.Errorf("queue order = %v, want 3", player.QueueOrder)
+	}
+	if runs := player.Stats["runs"]; runs != 5000.0 {
+		t.Errorf("stats runs = %v, want 5000", runs)
+	}
+}
+
+// TestConcurrentImportsDoNotDuplicate checks two uploads of the same file into one
+// auction add its players once: the second waits for the first and sees its rows
+func TestConcurrentImportsDoNotDuplicate(t *testing.T) {
+	root, _ := newTestAuctionService(t)
+	ctx := context.Background()
+	f := newAuctionFixture(t, root, "Import League", "2026")
+	players := NewPlayerService(f.repos)
+
+	const file = "Name,Country,Role,Base Price\nVirat,India,Batsman,2000\nRashid,Afghanistan,Bowler,2000\n"
+	imported := make([]int, 2)
+	var wg sync.WaitGroup
+	for i := range imported {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := players.Import(ctx, "players.csv", strings.NewReader(file), PlayerImportOptions{})
+			if err != nil {
+				t.Errorf("import %d: %v", i, err)
+				return
+			}
+			imported[i] = result.Imported
+		}(i)
+	}
+	wg.Wait()
+
+	if imported[0]+imported[1] != 2 {
+		t.Errorf("imports added %v players, want 2 between them", imported)
+	}
+	keys, err := f.repos.Players.FindIdentityKeys(ctx)
+	if err != nil {
+		t.Fatalf("find players: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("auction has %d players, want 2", len(keys))
+	}
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/auctionapp/backend/internal/models"
 	"github.com/auctionapp/backend/internal/repository"
@@ -60,6 +62,7 @@ func (s *PlayerService) Create(ctx context.Context, req models.CreatePlayerReque
 		Category:    "Set 1",
 		Stats:       req.Stats,
 		QueueOrder:  req.QueueOrder,
+		Uncapped:    req.Uncapped,
 	}
 	if req.ImageURL != "" {
 		player.ImageURL = &req.ImageURL
@@ -70,6 +73,11 @@ func (s *PlayerService) Create(ctx context.Context, req models.CreatePlayerReque
 	if player.Stats == nil {
 		player.Stats = make(map[string]interface{})
 	}
+	previous, err := s.previousTeam(ctx, req.PreviousTeamID)
+	if err != nil {
+		return nil, err
+	}
+	player.PreviousTeamID = previous
 
 	if err := s.repos.Players.Create(ctx, player); err != nil {
 		return nil, err
@@ -111,6 +119,14 @@ func (s *PlayerService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 	if req.QueueOrder != nil {
 		player.QueueOrder = req.QueueOrder
 	}
+	if req.Uncapped != nil {
+		player.Uncapped = *req.Uncapped
+	}
+	if req.PreviousTeamID != nil {
+		if player.PreviousTeamID, err = s.previousTeam(ctx, *req.PreviousTeamID); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repos.Players.Update(ctx, player); err != nil {
 		return nil, err
@@ -118,6 +134,23 @@ func (s *PlayerService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 	return player, nil
 }
 
+// previousTeam resolves the team holding a player's right to match, which must be one of
+// the auction's teams. An empty ID means the player has none.
+func (s *PlayerService) previousTeam(ctx context.Context, raw string) (*uuid.UUID, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, errors.New("invalid previous team ID")
+	}
+	if _, err := s.repos.Teams.FindByID(ctx, id); err != nil {
+		return nil, errors.New("previous team not found")
+	}
+	return &id, nil
+}
+
 // Delete deletes a player
 func (s *PlayerService) Delete(ctx context.Context, id uuid.UUID) error {
 	return s.repos.Players.Delete(ctx, id)
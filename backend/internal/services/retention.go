@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RetentionRulesKey is the settings key holding the retention rules
+const RetentionRulesKey = "retention_rules"
+
+// RetentionRules limit the players a team may keep before the auction and price them.
+// A limit of 0 means no limit.
+//
+//	{"max_retentions": 5, "max_overseas": 2, "max_uncapped": 2,
+//	 "slabs": [18000, 14000, 11000], "uncapped_price": 4000}
+//
+// The nth retention in a team's list costs the nth slab, and retentions past the last slab
+// cost the last one; with no slabs retentions are free. When uncapped_price is set an
+// uncapped player costs that instead and does not use up a slab.
+type RetentionRules struct {
+	MaxRetentions int
+	MaxOverseas   int
+	MaxUncapped   int
+	Slabs         []int64
+	UncappedPrice int64
+}
+
+// ParseRetentionRules reads a retention_rules setting value
+func ParseRetentionRules(value interface{}) (RetentionRules, error) {
+	var rules RetentionRules
+	v, ok := value.(map[string]interface{})
+	if !ok {
+		return rules, errors.New("retention_rules must be an object")
+	}
+
+	limits := map[string]*int{
+		"max_retentions": &rules.MaxRetentions,
+		"max_overseas":   &rules.MaxOverseas,
+		"max_uncapped":   &rules.MaxUncapped,
+	}
+	for key, limit := range limits {
+		raw, ok := v[key]
+		if !ok {
+			continue
+		}
+		n, ok := toInt64(raw)
+		if !ok || n < 0 {
+			return rules, fmt.Errorf("retention_rules.%s must be a non-negative whole number", key)
+		}
+		*limit = int(n)
+	}
+
+	if raw, ok := v["slabs"]; ok {
+		slabs, ok := raw.([]interface{})
+		if !ok {
+			return rules, errors.New("retention_rules.slabs must be a list of prices")
+		}
+		for i, item := range slabs {
+			price, ok := toInt64(item)
+			if !ok || price < 0 {
+				return rules, fmt.Errorf("retention_rules.slabs[%d] must be a non-negative whole number", i)
+			}
+			rules.Slabs = append(rules.Slabs, price)
+		}
+	}
+
+	if raw, ok := v["uncapped_price"]; ok {
+		price, ok := toInt64(raw)
+		if !ok || price < 0 {
+			return rules, errors.New("retention_rules.uncapped_price must be a non-negative whole number")
+		}
+		rules.UncappedPrice = price
+	}
+	return rules, nil
+}
+
+// loadRetentionRules reads the retention rules; a missing setting means no limits and
+// free retentions
+func loadRetentionRules(ctx context.Context, repos *repository.Repositories) (RetentionRules, error) {
+	value, err := repos.Settings.Get(ctx, RetentionRulesKey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return RetentionRules{}, nil
+	}
+	if err != nil {
+		return RetentionRules{}, fmt.Errorf("failed to read retention rules: %w", err)
+	}
+	return ParseRetentionRules(value)
+}
+
+// Costs prices a team's retention list in order
+func (r RetentionRules) Costs(players []*models.Player) []int64 {
+	costs := make([]int64, len(players))
+	slab := 0
+	for i, p := range players {
+		if p.Uncapped && r.UncappedPrice > 0 {
+			costs[i] = r.UncappedPrice
+			continue
+		}
+		if len(r.Slabs) > 0 {
+			costs[i] = r.Slabs[min(slab, len(r.Slabs)-1)]
+		}
+		slab++
+	}
+	return costs
+}
+
+// RetainPlayers replaces a team's retained players with the list given, with optional
+// badges. The team's previous retentions are released and refunded, the new list is checked
+// against the retention rules and the squad and overseas caps, and its cost is charged to
+// the purse, all in one transaction: either the whole list is kept or nothing changes.
+func (s *TeamService) RetainPlayers(ctx context.Context, teamID uuid.UUID, players []models.RetainedPlayer) error {
+	rules, err := loadRetentionRules(ctx, s.repos)
+	if err != nil {
+		return err
+	}
+	if rules.MaxRetentions > 0 && len(players) > rules.MaxRetentions {
+		return fmt.Errorf("a team may retain at most %d players", rules.MaxRetentions)
+	}
+
+	ids := make([]uuid.UUID, len(players))
+	seen := make(map[uuid.UUID]bool, len(players))
+	for i, p := range players {
+		id, err := uuid.Parse(p.PlayerID)
+		if err != nil {
+			return fmt.Errorf("invalid player ID %q", p.PlayerID)
+		}
+		if seen[id] {
+			return errors.New("a player is listed more than once")
+		}
+		seen[id] = true
+		ids[i] = id
+	}
+
+	return s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		// Lock the auction so no sale charges the purse between the check and the charge
+		auction, err := tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return errors.New("no active auction")
+		}
+		if _, err := tx.Teams.FindByID(ctx, teamID); err != nil {
+			return errors.New("team not found")
+		}
+
+		// Release and refund the team's current retentions
+		previous, err := tx.Players.GetRetainedByTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		var refund int64
+		for _, p := range previous {
+			if p.SoldPrice != nil {
+				refund += *p.SoldPrice
+			}
+		}
+		if err := tx.Players.ReleaseAllRetainedByTeam(ctx, teamID); err != nil {
+			return err
+		}
+		if refund > 0 {
+			if err := tx.Teams.UpdateSpent(ctx, teamID, -refund); err != nil {
+				return err
+			}
+		}
+
+		// Check the new list against the team as it stands without its retentions
+		team, err := tx.Teams.FindByID(ctx, teamID)
+		if err != nil {
+			return errors.New("team not found")
+		}
+		home := loadHomeCountry(ctx, tx)
+		var overseas, uncapped int
+		retained := make([]*models.Player, len(ids))
+		for i, id := range ids {
+			player, err := tx.Players.FindByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("player %s not found", id)
+			}
+			if player.Status != "available" && player.Status != "unsold" {
+				return fmt.Errorf("%s is already %s", player.Name, player.Status)
+			}
+			if auction.CurrentPlayerID != nil && *auction.CurrentPlayerID == id {
+				return fmt.Errorf("%s is under the hammer", player.Name)
+			}
+			if isOverseas(player, home) {
+				overseas++
+			}
+			if player.Uncapped {
+				uncapped++
+			}
+			retained[i] = player
+		}
+		costs := rules.Costs(retained)
+		var total int64
+		for _, cost := range costs {
+			total += cost
+		}
+
+		if rules.MaxOverseas > 0 && overseas > rules.MaxOverseas {
+			return fmt.Errorf("a team may retain at most %d overseas players", rules.MaxOverseas)
+		}
+		if rules.MaxUncapped > 0 && uncapped > rules.MaxUncapped {
+			return fmt.Errorf("a team may retain at most %d uncapped players", rules.MaxUncapped)
+		}
+		if team.MaxPlayers > 0 && team.PlayerCount+len(ids) > team.MaxPlayers {
+			return fmt.Errorf("%s squad would exceed %d players", team.ShortName, team.MaxPlayers)
+		}
+		if team.MaxForeign > 0 && team.ForeignCount+overseas > team.MaxForeign {
+			return fmt.Errorf("%s would exceed the overseas limit of %d players", team.ShortName, team.MaxForeign)
+		}
+		if remaining := team.Budget - team.Spent; total > remaining {
+			return fmt.Errorf("retentions cost %d but %s has only %d left in its purse", total, team.ShortName, remaining)
+		}
+
+		for i, id := range ids {
+			if err := tx.Players.RetainPlayer(ctx, id, teamID, players[i].Badge, costs[i]); err != nil {
+				return err
+			}
+		}
+		if total > 0 {
+			return tx.Teams.UpdateSpent(ctx, teamID, total)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/auctionapp/backend/internal/models"
+)
+
+func TestParseRetentionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    RetentionRules
+		wantErr bool
+	}{
+		{name: "empty", value: map[string]interface{}{}, want: RetentionRules{}},
+		{
+			name: "full",
+			value: map[string]interface{}{
+				"max_retentions": 5.0, "max_overseas": 2.0, "max_uncapped": 2.0,
+				"slabs": []interface{}{18000.0, 14000.0, 11000.0}, "uncapped_price": 4000.0,
+			},
+			want: RetentionRules{MaxRetentions: 5, MaxOverseas: 2, MaxUncapped: 2, Slabs: []int64{18000, 14000, 11000}, UncappedPrice: 4000},
+		},
+		{
+			name:  "the seeded default",
+			value: map[string]interface{}{"max_retentions": 0.0, "max_overseas": 0.0, "max_uncapped": 0.0, "slabs": []interface{}{}, "uncapped_price": 0.0},
+			want:  RetentionRules{},
+		},
+		{name: "not an object", value: []interface{}{5.0}, wantErr: true},
+		{name: "negative limit", value: map[string]interface{}{"max_overseas": -1.0}, wantErr: true},
+		{name: "fractional limit", value: map[string]interface{}{"max_retentions": 2.5}, wantErr: true},
+		{name: "slabs not a list", value: map[string]interface{}{"slabs": 18000.0}, wantErr: true},
+		{name: "negative slab", value: map[string]interface{}{"slabs": []interface{}{18000.0, -1.0}}, wantErr: true},
+		{name: "string slab", value: map[string]interface{}{"slabs": []interface{}{"18000"}}, wantErr: true},
+		{name: "negative uncapped price", value: map[string]interface{}{"uncapped_price": -4000.0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetentionRules(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseRetentionRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetentionCosts(t *testing.T) {
+	capped, uncapped := &models.Player{}, &models.Player{Uncapped: true}
+	slabs := []int64{18000, 14000, 11000}
+
+	tests := []struct {
+		name    string
+		rules   RetentionRules
+		players []*models.Player
+		want    []int64
+	}{
+		{"no retentions", RetentionRules{Slabs: slabs}, nil, []int64{}},
+		{"slabs in order", RetentionRules{Slabs: slabs}, []*models.Player{capped, capped}, []int64{18000, 14000}},
+		{"past the last slab", RetentionRules{Slabs: slabs}, []*models.Player{capped, capped, capped, capped, capped}, []int64{18000, 14000, 11000, 11000, 11000}},
+		{"no slabs are free", RetentionRules{}, []*models.Player{capped, capped}, []int64{0, 0}},
+		{"uncapped at the flat price", RetentionRules{Slabs: slabs, UncappedPrice: 4000}, []*models.Player{uncapped, capped, uncapped, capped}, []int64{4000, 18000, 4000, 14000}},
+		{"uncapped on a slab without a flat price", RetentionRules{Slabs: slabs}, []*models.Player{uncapped, capped}, []int64{18000, 14000}},
+		{"uncapped price without slabs", RetentionRules{UncappedPrice: 4000}, []*models.Player{capped, uncapped}, []int64{0, 4000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Costs(tt.players); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Costs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadRetentionRulesReportsReadErrors checks a failed read is not taken for a league
+// without retention rules, which would let retentions through unpriced
+func TestLoadRetentionRulesReportsReadErrors(t *testing.T) {
+	_, repos := newTestAuctionService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if rules, err := loadRetentionRules(ctx, repos); err == nil {
+		t.Fatalf("loadRetentionRules on a failed read = %+v, want an error", rules)
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Right to match (RTM): when a player with a previous team is hammered to another team,
+// the previous team may use one of its RTM cards to take the player at the winning bid.
+// The hammer opens a window (auctions.rtm_team_id) that runs on the lot timer; the former
+// team accepts or declines, or the window lapses and the winning bidder gets the player.
+
+// RTMCardsKey is the settings key for each team's RTM card allotment: a number for every
+// team, or an object of team ID or short name to number, with "default" for the rest
+const RTMCardsKey = "rtm_cards"
+
+// RTMWindowKey is the settings key for the seconds a former team has to answer
+const RTMWindowKey = "rtm_window"
+
+const defaultRTMWindow = 20
+
+// How a right-to-match window ends, as sent in auction:rtm events after "offered"
+const (
+	RTMOffered  = "offered"
+	RTMMatched  = "matched"  // the former team took the player
+	RTMDeclined = "declined" // the former team let the winning bidder have them
+	RTMLapsed   = "lapsed"   // the window ran out, same as declined
+)
+
+// ErrNoRTMWindow is returned when answering a right to match nobody was offered
+var ErrNoRTMWindow = errors.New("no right-to-match window is open")
+
+// RTMResult is how a right-to-match window closed and who the player was sold to
+type RTMResult struct {
+	Stage       string         `json:"stage"`
+	Player      *models.Player `json:"player"`
+	Team        *models.Team   `json:"team"`         // the team the player was sold to
+	WinningTeam *models.Team   `json:"winning_team"` // the team that won the bidding
+	Amount      int64          `json:"amount"`
+}
+
+// rtmRejection is a reason a team may not match, as opposed to a failed query
+type rtmRejection struct{ error }
+
+// rtmAllotment is how many RTM cards each team holds for the auction
+type rtmAllotment struct {
+	all   int
+	teams map[string]int // by lower-cased team ID or short name
+}
+
+// cards returns a team's allotment
+func (a rtmAllotment) cards(team *models.Team) int {
+	if n, ok := a.teams[team.ID.String()]; ok {
+		return n
+	}
+	if n, ok := a.teams[strings.ToLower(team.ShortName)]; ok {
+		return n
+	}
+	return a.all
+}
+
+// parseRTMCards reads the rtm_cards setting
+func parseRTMCards(value interface{}) (rtmAllotment, error) {
+	invalid := errors.New("rtm_cards must be a non-negative whole number, or an object of team ID or short name (or \"default\") to one")
+	if n, ok := toInt64(value); ok {
+		if n < 0 {
+			return rtmAllotment{}, invalid
+		}
+		return rtmAllotment{all: int(n)}, nil
+	}
+	byTeam, ok := value.(map[string]interface{})
+	if !ok {
+		return rtmAllotment{}, invalid
+	}
+	allotment := rtmAllotment{teams: make(map[string]int, len(byTeam))}
+	for key, v := range byTeam {
+		n, ok := toInt64(v)
+		if !ok || n < 0 {
+			return rtmAllotment{}, invalid
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "default" {
+			allotment.all = int(n)
+		} else {
+			allotment.teams[key] = int(n)
+		}
+	}
+	return allotment, nil
+}
+
+// loadRTMCards reads the auction's RTM card allotment (none when unset or invalid)
+func loadRTMCards(ctx context.Context, repos *repository.Repositories) rtmAllotment {
+	value, err := repos.Settings.Get(ctx, RTMCardsKey)
+	if err != nil {
+		return rtmAllotment{}
+	}
+	allotment, err := parseRTMCards(value)
+	if err != nil {
+		return rtmAllotment{}
+	}
+	return allotment
+}
+
+// rtmWindow returns the seconds a former team has to answer
+func (s *AuctionService) rtmWindow(ctx context.Context) int {
+	return loadRTMWindow(ctx, s.repos)
+}
+
+// loadRTMWindow reads the right-to-match window through repos
+func loadRTMWindow(ctx context.Context, repos *repository.Repositories) int {
+	value, err := repos.Settings.Get(ctx, RTMWindowKey)
+	if err != nil {
+		return defaultRTMWindow
+	}
+	if seconds, ok := toInt64(value); ok && seconds > 0 {
+		return int(seconds)
+	}
+	return defaultRTMWindow
+}
+
+// rtmCardsLeft returns each team's unused RTM cards. Cards used on sales that were later
+// reversed are handed back.
+func (s *AuctionService) rtmCardsLeft(ctx context.Context, repos *repository.Repositories, teams []models.Team) (map[uuid.UUID]int, error) {
+	ids := make([]uuid.UUID, len(teams))
+	for i, team := range teams {
+		ids[i] = team.ID
+	}
+	used, err := repos.Sales.CountRTMByTeam(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	allotment := loadRTMCards(ctx, repos)
+	left := make(map[uuid.UUID]int, len(teams))
+	for i := range teams {
+		n := allotment.cards(&teams[i]) - used[teams[i].ID]
+		if n < 0 {
+			n = 0
+		}
+		left[teams[i].ID] = n
+	}
+	return left, nil
+}
+
+// checkRTM ensures a team could match amount for the player: it has a card left, room in
+// its squad and enough purse once its minimum squad is reserved. It returns the cards left.
+// When the team may not match, the error is an rtmRejection. Everything is read through
+// repos, the caller's transaction, which holds the auction lock.
+func (s *AuctionService) checkRTM(ctx context.Context, repos *repository.Repositories, team *models.Team, player *models.Player, amount int64) (int, error) {
+	left, err := s.rtmCardsLeft(ctx, repos, []models.Team{*team})
+	if err != nil {
+		return 0, err
+	}
+	if left[team.ID] <= 0 {
+		return 0, rtmRejection{fmt.Errorf("%s has no right-to-match cards left", team.ShortName)}
+	}
+	if err := checkSquadLimits(team, player, loadHomeCountry(ctx, repos)); err != nil {
+		return 0, rtmRejection{err}
+	}
+	minPrice := loadMinPlayerPrice(ctx, repos, loadBidLadder(ctx, repos))
+	maxBid := maxAllowedBid(team, loadMinSquadSize(ctx, repos), minPrice)
+	if amount > maxBid {
+		return 0, rtmRejection{fmt.Errorf("%s cannot match %d - its max bid is %d", team.ShortName, amount, maxBid)}
+	}
+	return left[team.ID], nil
+}
+
+// openRTM is called by the hammer inside its transaction. When the player's previous team
+// lost the bidding but could match it, the team's window is opened on the auction row and
+// the offer returned; otherwise it returns nil and the sale goes ahead. Failed queries are
+// returned so the hammer rolls back rather than selling without the offer.
+func (s *AuctionService) openRTM(ctx context.Context, tx *repository.Repositories, auction *models.Auction, player *models.Player, winning *models.Bid) (*models.RTMOffer, error) {
+	if player.PreviousTeamID == nil || *player.PreviousTeamID == winning.TeamID {
+		return nil, nil
+	}
+	team, err := tx.Teams.FindByID(ctx, *player.PreviousTeamID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The previous team is not in this auction
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cardsLeft, err := s.checkRTM(ctx, tx, team, player, winning.Amount)
+	var rejected rtmRejection
+	if errors.As(err, &rejected) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Auctions.SetRTMTeam(ctx, auction.ID, team.ID); err != nil {
+		return nil, err
+	}
+	auction.RTMTeamID = &team.ID
+
+	winningTeam, err := tx.Teams.FindByID(ctx, winning.TeamID)
+	if err != nil {
+		return nil, errors.New("team not found")
+	}
+	return &models.RTMOffer{
+		Player:      player,
+		Team:        team,
+		WinningTeam: winningTeam,
+		Amount:      winning.Amount,
+		CardsLeft:   cardsLeft,
+		Window:      loadRTMWindow(ctx, tx),
+	}, nil
+}
+
+// loadRTM returns the open right-to-match window of the lot, if any
+func (s *AuctionService) loadRTM(ctx context.Context, auction *models.Auction, lot *models.LotPatch) *models.RTMOffer {
+	if auction.RTMTeamID == nil || lot.CurrentPlayer == nil || lot.CurrentBid == nil {
+		return nil
+	}
+	team, err := s.repos.Teams.FindByID(ctx, *auction.RTMTeamID)
+	if err != nil {
+		return nil
+	}
+	left, err := s.rtmCardsLeft(ctx, s.repos, []models.Team{*team})
+	if err != nil {
+		return nil
+	}
+	return &models.RTMOffer{
+		Player:      lot.CurrentPlayer,
+		Team:        team,
+		WinningTeam: lot.CurrentBidder,
+		Amount:      *lot.CurrentBid,
+		CardsLeft:   left[team.ID],
+		Window:      s.rtmWindow(ctx),
+	}
+}
+
+// RespondRTM answers the open right-to-match window: accept takes the player at the
+// winning bid with one of the former team's cards, decline sells them to the winning
+// bidder. teamID must be the former team's; nil answers for it (the host acting for it).
+func (s *AuctionService) RespondRTM(ctx context.Context, teamID *uuid.UUID, accept bool) (*RTMResult, error) {
+	stage := RTMDeclined
+	if accept {
+		stage = RTMMatched
+	}
+	return s.closeRTM(ctx, teamID, stage)
+}
+
+// closeRTM finalises the sale held by the right-to-match window
+func (s *AuctionService) closeRTM(ctx context.Context, teamID *uuid.UUID, stage string) (*RTMResult, error) {
+	defer s.InvalidateState(ctx)
+
+	result := &RTMResult{Stage: stage}
+	var sale *models.Sale
+	err := s.repos.WithTx(ctx, func(tx *repository.Repositories) error {
+		auction, err := tx.Auctions.GetCurrentForUpdate(ctx)
+		if err != nil {
+			return errors.New("no active auction")
+		}
+		if auction.RTMTeamID == nil || auction.CurrentPlayerID == nil || auction.CurrentBidderID == nil || auction.CurrentBid == nil {
+			return ErrNoRTMWindow
+		}
+		if teamID != nil && *teamID != *auction.RTMTeamID {
+			return errors.New("the right to match on this player belongs to another team")
+		}
+
+		// The winning bid is the leading bidder's latest at the current bid; after a tie
+		// was allocated with SellToTeam it need not be the last one accepted
+		bids, err := tx.Bids.FindByPlayer(ctx, *auction.CurrentPlayerID)
+		if err != nil {
+			return err
+		}
+		var winning *models.Bid
+		for i := range bids {
+			if !bids[i].Voided && bids[i].TeamID == *auction.CurrentBidderID && bids[i].Amount == *auction.CurrentBid {
+				winning = &bids[i]
+				break
+			}
+		}
+		if winning == nil {
+			return errors.New("current bid does not match the recorded bids")
+		}
+		result.Amount = winning.Amount
+		if result.WinningTeam, err = tx.Teams.FindByID(ctx, winning.TeamID); err != nil {
+			return errors.New("team not found")
+		}
+
+		if stage == RTMMatched {
+			// Purses or squads may have changed since the window opened
+			player, err := tx.Players.FindByID(ctx, *auction.CurrentPlayerID)
+			if err != nil {
+				return errors.New("player not found")
+			}
+			team, err := tx.Teams.FindByID(ctx, *auction.RTMTeamID)
+			if err != nil {
+				return errors.New("team not found")
+			}
+			if _, err := s.checkRTM(ctx, tx, team, player, winning.Amount); err != nil {
+				return err
+			}
+
+			// The match is recorded as the former team's bid at the same amount
+			winning = &models.Bid{
+				AuctionID: auction.ID,
+				PlayerID:  *auction.CurrentPlayerID,
+				TeamID:    team.ID,
+				Amount:    winning.Amount,
+			}
+			if err := tx.Bids.Create(ctx, winning); err != nil {
+				return err
+			}
+		}
+
+		sale, err = s.finaliseSale(ctx, tx, auction, winning, stage == RTMMatched)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.StopTimer()
+
+	result.Player, _ = s.repos.Players.FindByID(ctx, sale.PlayerID)
+	result.Team, _ = s.repos.Teams.FindByID(ctx, sale.TeamID)
+	return result, nil
+}
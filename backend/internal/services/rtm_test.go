@@ -0,0 +1,89 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestParseRTMCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    rtmAllotment
+		wantErr bool
+	}{
+		{name: "every team", value: 2.0, want: rtmAllotment{all: 2}},
+		{name: "int", value: 3, want: rtmAllotment{all: 3}},
+		{name: "none", value: 0.0, want: rtmAllotment{}},
+		{
+			name:  "by team",
+			value: map[string]interface{}{" CSK ": 2.0, "default": 1.0},
+			want:  rtmAllotment{all: 1, teams: map[string]int{"csk": 2}},
+		},
+		{
+			name:  "by team without a default",
+			value: map[string]interface{}{"MI": 0.0},
+			want:  rtmAllotment{teams: map[string]int{"mi": 0}},
+		},
+		{name: "negative", value: -1.0, wantErr: true},
+		{name: "fraction", value: 1.5, wantErr: true},
+		{name: "string", value: "2", wantErr: true},
+		{name: "list", value: []interface{}{1.0}, wantErr: true},
+		{name: "negative for a team", value: map[string]interface{}{"csk": -1.0}, wantErr: true},
+		{name: "string for a team", value: map[string]interface{}{"csk": "2"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRTMCards(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseRTMCards(%v) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRTMAllotmentCards(t *testing.T) {
+	csk := &models.Team{ID: uuid.New(), ShortName: "CSK"}
+	mi := &models.Team{ID: uuid.New(), ShortName: "MI"}
+	rcb := &models.Team{ID: uuid.New(), ShortName: "RCB"}
+
+	allotment := rtmAllotment{all: 1, teams: map[string]int{
+		"csk":           3,
+		mi.ID.String():  0,
+		"mi":            2, // the ID entry wins
+		rcb.ID.String(): 4,
+	}}
+	tests := []struct {
+		name string
+		team *models.Team
+		want int
+	}{
+		{"by short name", csk, 3},
+		{"by ID over short name", mi, 0},
+		{"by ID", rcb, 4},
+		{"default", &models.Team{ID: uuid.New(), ShortName: "KKR"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allotment.cards(tt.team); got != tt.want {
+				t.Fatalf("cards(%s) = %d, want %d", tt.team.ShortName, got, tt.want)
+			}
+		})
+	}
+
+	if got := (rtmAllotment{}).cards(csk); got != 0 {
+		t.Errorf("cards with no allotment = %d, want 0", got)
+	}
+}
@@ -1,31 +1,219 @@
 package services
 
 import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
 	"github.com/auctionapp/backend/internal/config"
 	"github.com/auctionapp/backend/internal/repository"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
-// Services holds all service instances
+// Services holds all service instances. Those returned by ForAuction work on one
+// auction's teams, players, settings and live state; Auth and Users are shared.
+// Those returned by ForOrganization only see one organisation's users, auctions and archives.
 type Services struct {
-	Auth     *AuthService
-	Teams    *TeamService
-	Players  *PlayerService
-	Auction  *AuctionService
-	Users    *UserService
-	Settings *SettingsService
-	Stats    *StatsService
+	Organizations *OrganizationService
+	Auth          *AuthService
+	Teams         *TeamService
+	Players       *PlayerService
+	Auction       *AuctionService
+	Auctions      *AuctionsService
+	Users         *UserService
+	Settings      *SettingsService
+	Stats         *StatsService
+	Exports       *ExportService
+	Overlay       *OverlayService
+	Archives      *ArchiveService
+
+	scopes *auctionScopes
+}
+
+// auctionTimerScan is how often RunTimers looks for auctions that went live on another instance
+const auctionTimerScan = 5 * time.Second
+
+// auctionScopes keeps the services of every auction this process has served, so each
+// auction has one AuctionService (and so one lot timer and state cache) per process
+type auctionScopes struct {
+	mu     sync.Mutex
+	root   *Services
+	repos  *repository.Repositories
+	redis  *redis.Client
+	scoped map[uuid.UUID]*scopedServices
+
+	// Set by SetTimerCallbacks and RunTimers
+	callbacks TimerCallbacks
+	timerCtx  context.Context
+	timers    sync.WaitGroup
+}
+
+// scopedServices is one auction's services and the cancel func of its lot timer
+type scopedServices struct {
+	svc       *Services
+	stopTimer context.CancelFunc
 }
 
 // NewServices creates all service instances
 func NewServices(repos *repository.Repositories, redis *redis.Client, cfg *config.Config) *Services {
-	return &Services{
+	auction := NewAuctionService(repos, redis)
+	svc := &Services{
 		Auth:     NewAuthService(repos, cfg, redis),
 		Teams:    NewTeamService(repos),
 		Players:  NewPlayerService(repos),
-		Auction:  NewAuctionService(repos, redis),
+		Auction:  auction,
 		Users:    NewUserService(repos),
 		Settings: NewSettingsService(repos),
 		Stats:    NewStatsService(repos),
+		Exports:  NewExportService(repos),
+		Overlay:  NewOverlayService(auction),
+		Archives: NewArchiveService(repos),
+	}
+	svc.scopes = &auctionScopes{root: svc, repos: repos, redis: redis, scoped: map[uuid.UUID]*scopedServices{}}
+	svc.Auctions = NewAuctionsService(repos, svc.scopes)
+	svc.Organizations = NewOrganizationService(repos, svc.Auctions)
+	return svc
+}
+
+// ForAuction returns the services of one auction
+func (s *Services) ForAuction(id uuid.UUID) *Services {
+	return s.scopes.get(id)
+}
+
+// ForOrganization returns services whose users and auctions are limited to one
+// organisation, or these services when org is nil (a platform super admin or the public)
+func (s *Services) ForOrganization(org *uuid.UUID) *Services {
+	if org == nil {
+		return s
+	}
+	repos := s.scopes.repos.ForOrganization(*org)
+	scoped := *s
+	scoped.Users = NewUserService(repos)
+	scoped.Auctions = NewAuctionsService(repos, s.scopes)
+	scoped.Archives = NewArchiveService(repos)
+	return &scoped
+}
+
+// TimerCallbacks returns the functions an auction's lot timer calls on every tick and on
+// expiry, given the auction's services. It must not call ForAuction.
+type TimerCallbacks func(auctionID uuid.UUID, svc *Services) (onTick func(remaining int), onExpired func())
+
+// SetTimerCallbacks registers the lot timer callbacks of every auction
+func (s *Services) SetTimerCallbacks(callbacks TimerCallbacks) {
+	s.scopes.mu.Lock()
+	defer s.scopes.mu.Unlock()
+	s.scopes.callbacks = callbacks
+	for id, scoped := range s.scopes.scoped {
+		scoped.svc.Auction.SetTimerCallbacks(callbacks(id, scoped.svc))
+	}
+}
+
+// RunTimers drives the lot countdown of every auction until ctx is cancelled, then waits
+// for the timers to hand over their leases. Auctions are picked up when first used and
+// whenever one is live or paused, so a countdown continues after a restart.
+func (s *Services) RunTimers(ctx context.Context) {
+	scopes := s.scopes
+	scopes.mu.Lock()
+	scopes.timerCtx = ctx
+	for id, scoped := range scopes.scoped {
+		scopes.startTimerLocked(id, scoped)
+	}
+	scopes.mu.Unlock()
+
+	ticker := time.NewTicker(auctionTimerScan)
+	defer ticker.Stop()
+	for {
+		scopes.followLiveAuctions(ctx)
+		select {
+		case <-ctx.Done():
+			scopes.timers.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// followLiveAuctions makes sure every live or paused auction has a timer on this process
+func (a *auctionScopes) followLiveAuctions(ctx context.Context) {
+	auctions, err := a.repos.Auctions.List(ctx)
+	if err != nil {
+		return
+	}
+	for _, auction := range auctions {
+		if auction.Status == "live" || auction.Status == "paused" {
+			a.get(auction.ID)
+		}
+	}
+}
+
+// get returns an auction's services, creating them (and starting their timer) on first use
+func (a *auctionScopes) get(id uuid.UUID) *Services {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if scoped, ok := a.scoped[id]; ok {
+		return scoped.svc
+	}
+
+	// The auction's organisation bounds anything not kept per auction, such as its users
+	repos := a.repos.ForAuction(id)
+	if auction, err := a.repos.Auctions.FindByID(context.Background(), id); err == nil && auction.OrganizationID != nil {
+		repos = repos.ForOrganization(*auction.OrganizationID)
+	}
+	auction := NewAuctionService(repos, a.redis)
+	svc := &Services{
+		Organizations: a.root.Organizations,
+		Auth:          a.root.Auth,
+		Teams:         NewTeamService(repos),
+		Players:       NewPlayerService(repos),
+		Auction:       auction,
+		Auctions:      a.root.Auctions,
+		Users:         a.root.Users,
+		Settings:      NewSettingsService(repos),
+		Stats:         NewStatsService(repos),
+		Exports:       NewExportService(repos),
+		Overlay:       NewOverlayService(auction),
+		Archives:      NewArchiveService(repos),
+		scopes:        a,
+	}
+	if a.callbacks != nil {
+		auction.SetTimerCallbacks(a.callbacks(id, svc))
+	}
+	scoped := &scopedServices{svc: svc}
+	a.scoped[id] = scoped
+	a.startTimerLocked(id, scoped)
+	return svc
+}
+
+// startTimerLocked runs an auction's lot timer once RunTimers has started. Callers hold a.mu.
+func (a *auctionScopes) startTimerLocked(id uuid.UUID, scoped *scopedServices) {
+	if a.timerCtx == nil || scoped.stopTimer != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(a.timerCtx)
+	scoped.stopTimer = cancel
+	a.timers.Add(1)
+	go func() {
+		defer a.timers.Done()
+		// Panic recovery for the timer loop
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("CRITICAL: Auction %s timer panic recovered: %v", id, r)
+			}
+		}()
+		scoped.svc.Auction.RunTimer(ctx)
+	}()
+}
+
+// drop stops an auction's timer and forgets its services (when the auction is deleted)
+func (a *auctionScopes) drop(id uuid.UUID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if scoped, ok := a.scoped[id]; ok {
+		if scoped.stopTimer != nil {
+			scoped.stopTimer()
+		}
+		delete(a.scoped, id)
 	}
 }
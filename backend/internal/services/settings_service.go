@@ -2,10 +2,19 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/auctionapp/backend/internal/repository"
 )
 
+// AutoCloseKey is the settings key for the default auto-close mode of new auctions
+const AutoCloseKey = "auto_close_on_timer"
+
+// ErrInvalidSettings wraps the reason Update rejected a settings value
+var ErrInvalidSettings = errors.New("invalid settings")
+
 // SettingsService handles settings operations
 type SettingsService struct {
 	repos *repository.Repositories
@@ -21,8 +30,51 @@ func (s *SettingsService) GetAll(ctx context.Context) (map[string]interface{}, e
 	return s.repos.Settings.GetAll(ctx)
 }
 
+// Validate checks settings values that the auction engine depends on
+func (s *SettingsService) Validate(settings map[string]interface{}) error {
+	if value, ok := settings[BidIncrementsKey]; ok {
+		if _, err := ParseBidLadder(value); err != nil {
+			return err
+		}
+	}
+	if value, ok := settings[MinSquadSizeKey]; ok {
+		if size, ok := toInt64(value); !ok || size < 0 {
+			return errors.New("min_squad_size must be a non-negative whole number")
+		}
+	}
+	if value, ok := settings[AutoCloseKey]; ok {
+		if _, ok := value.(bool); !ok {
+			return errors.New("auto_close_on_timer must be true or false")
+		}
+	}
+	if value, ok := settings[HomeCountryKey]; ok {
+		if country, ok := value.(string); !ok || strings.TrimSpace(country) == "" {
+			return errors.New("home_country must be a non-empty country name")
+		}
+	}
+	if value, ok := settings[RTMCardsKey]; ok {
+		if _, err := parseRTMCards(value); err != nil {
+			return err
+		}
+	}
+	if value, ok := settings[RTMWindowKey]; ok {
+		if seconds, ok := toInt64(value); !ok || seconds <= 0 {
+			return errors.New("rtm_window must be a positive number of seconds")
+		}
+	}
+	if value, ok := settings[RetentionRulesKey]; ok {
+		if _, err := ParseRetentionRules(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Update updates multiple settings
 func (s *SettingsService) Update(ctx context.Context, settings map[string]interface{}) error {
+	if err := s.Validate(settings); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
 	return s.repos.Settings.SetBulk(ctx, settings)
 }
 
@@ -0,0 +1,29 @@
+package services
+
+import "testing"
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		wantErr  bool
+	}{
+		{"unrelated keys", map[string]interface{}{"tournament_name": "League"}, false},
+		{"ladder", map[string]interface{}{BidIncrementsKey: []interface{}{2000.0, 3000.0}}, false},
+		{"bad ladder", map[string]interface{}{BidIncrementsKey: []interface{}{3000.0, 2000.0}}, true},
+		{"min squad", map[string]interface{}{MinSquadSizeKey: 18.0}, false},
+		{"negative min squad", map[string]interface{}{MinSquadSizeKey: -1.0}, true},
+		{"auto close", map[string]interface{}{AutoCloseKey: true}, false},
+		{"auto close not a bool", map[string]interface{}{AutoCloseKey: "yes"}, true},
+		{"home country", map[string]interface{}{HomeCountryKey: "Australia"}, false},
+		{"blank home country", map[string]interface{}{HomeCountryKey: "  "}, true},
+	}
+	svc := NewSettingsService(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.Validate(tt.settings); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
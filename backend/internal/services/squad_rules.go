@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/repository"
+	"github.com/auctionapp/backend/internal/utils"
+)
+
+// HomeCountryKey is the settings key for the country whose players are not overseas
+const HomeCountryKey = "home_country"
+
+const defaultHomeCountry = "India"
+
+// MinSquadSizeKey is the settings key for the number of players every team must end with
+const MinSquadSizeKey = "min_squad_size"
+
+// homeCountry returns the configured home country
+func (s *AuctionService) homeCountry(ctx context.Context) string {
+	return loadHomeCountry(ctx, s.repos)
+}
+
+// loadHomeCountry reads the home country setting, falling back to the default
+func loadHomeCountry(ctx context.Context, repos *repository.Repositories) string {
+	value, err := repos.Settings.Get(ctx, HomeCountryKey)
+	if err != nil {
+		return defaultHomeCountry
+	}
+	if country, ok := value.(string); ok && strings.TrimSpace(country) != "" {
+		return country
+	}
+	return defaultHomeCountry
+}
+
+// isOverseas reports whether a player counts against a team's overseas cap. The home
+// country may be stored by name or as its flag emoji.
+func isOverseas(player *models.Player, homeCountry string) bool {
+	return !utils.SameCountry(player.Country, homeCountry)
+}
+
+// checkSquadLimits ensures buying the player would not break the team's squad or overseas cap.
+// Callers holding the auction lock read homeCountry before taking it or through their transaction.
+func checkSquadLimits(team *models.Team, player *models.Player, homeCountry string) error {
+	if team.MaxPlayers > 0 && team.PlayerCount >= team.MaxPlayers {
+		return fmt.Errorf("%s squad is full (%d/%d players)", team.ShortName, team.PlayerCount, team.MaxPlayers)
+	}
+	if team.MaxForeign > 0 && isOverseas(player, homeCountry) && team.ForeignCount >= team.MaxForeign {
+		return fmt.Errorf("%s has reached the overseas limit (%d/%d players)", team.ShortName, team.ForeignCount, team.MaxForeign)
+	}
+	return nil
+}
+
+// minSquadSize returns the configured minimum squad size (0 disables the purse reserve)
+func (s *AuctionService) minSquadSize(ctx context.Context) int {
+	return loadMinSquadSize(ctx, s.repos)
+}
+
+// loadMinSquadSize reads the minimum squad size setting
+func loadMinSquadSize(ctx context.Context, repos *repository.Repositories) int {
+	value, err := repos.Settings.Get(ctx, MinSquadSizeKey)
+	if err != nil {
+		return 0
+	}
+	return parseMinSquadSize(value)
+}
+
+// parseMinSquadSize reads a stored minimum squad size; anything but a whole,
+// non-negative number means no minimum
+func parseMinSquadSize(value interface{}) int {
+	size, ok := toInt64(value)
+	if !ok || size < 0 {
+		return 0
+	}
+	return int(size)
+}
+
+// minPlayerPrice returns the least a team can pay for any remaining player:
+// the cheapest base price left in the pool, but never below the first ladder rung
+func (s *AuctionService) minPlayerPrice(ctx context.Context, ladder BidLadder) int64 {
+	return loadMinPlayerPrice(ctx, s.repos, ladder)
+}
+
+// loadMinPlayerPrice is minPlayerPrice read through repos
+func loadMinPlayerPrice(ctx context.Context, repos *repository.Repositories, ladder BidLadder) int64 {
+	minPrice := ladder[0]
+	if poolMin, err := repos.Players.MinBasePrice(ctx); err == nil && poolMin > minPrice {
+		minPrice = poolMin
+	}
+	return minPrice
+}
+
+// maxAllowedBid returns the most a team may bid on the player on the block while
+// keeping enough purse to fill the rest of its minimum squad at minPrice each
+func maxAllowedBid(team *models.Team, minSquadSize int, minPrice int64) int64 {
+	remaining := team.Budget - team.Spent
+	target := minSquadSize
+	if team.MaxPlayers > 0 && target > team.MaxPlayers {
+		target = team.MaxPlayers
+	}
+	// The player being bid on fills one slot
+	slotsNeeded := target - team.PlayerCount - 1
+	if slotsNeeded < 0 {
+		slotsNeeded = 0
+	}
+	maxBid := remaining - int64(slotsNeeded)*minPrice
+	if maxBid < 0 {
+		return 0
+	}
+	return maxBid
+}
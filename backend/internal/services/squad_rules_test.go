@@ -0,0 +1,86 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/auctionapp/backend/internal/models"
+)
+
+func TestIsOverseas(t *testing.T) {
+	tests := []struct {
+		country, home string
+		want          bool
+	}{
+		{"India", "India", false},
+		{" india ", "India", false},
+		{"🇮🇳", "India", false}, // the flag spelling the baseline data used
+		{"🇮🇳", " india", false},
+		{"Australia", "India", true},
+		{"🇦🇺", "India", true},
+		{"Australia", "Australia", false},
+		{"🇦🇺", "Australia", false},
+		{"🇮🇳", "Australia", true},
+		{"England", "England", false},
+		{"🏴󠁧󠁢󠁥󠁮󠁧󠁿", "England", false},
+		{"India", "🇮🇳", false}, // a home country stored as a flag
+		{"🇮🇳", "🇮🇳", false},
+		{"Australia", "🇮🇳", true},
+		{"UAE", "United Arab Emirates", false},
+		{"Atlantis", "Atlantis", false}, // unknown countries still match by name
+		{"", "Atlantis", true},
+	}
+	for _, tt := range tests {
+		player := &models.Player{Country: tt.country}
+		if got := isOverseas(player, tt.home); got != tt.want {
+			t.Errorf("isOverseas(%q, %q) = %v, want %v", tt.country, tt.home, got, tt.want)
+		}
+	}
+}
+
+func TestMaxAllowedBid(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     models.Team
+		minSquad int
+		minPrice int64
+		want     int64
+	}{
+		{"no minimum squad", models.Team{Budget: 10_000, Spent: 4_000}, 0, 500, 6_000},
+		{"reserve for the rest", models.Team{Budget: 10_000, Spent: 4_000, PlayerCount: 5}, 10, 500, 4_000},
+		{"last slot needs no reserve", models.Team{Budget: 10_000, Spent: 4_000, PlayerCount: 9}, 10, 500, 6_000},
+		{"minimum already reached", models.Team{Budget: 10_000, Spent: 4_000, PlayerCount: 12}, 10, 500, 6_000},
+		{"minimum capped at max players", models.Team{Budget: 10_000, MaxPlayers: 5, PlayerCount: 2}, 10, 1_000, 8_000},
+		{"reserve exceeds purse", models.Team{Budget: 10_000, Spent: 9_000}, 10, 500, 0},
+		{"purse spent", models.Team{Budget: 10_000, Spent: 10_000}, 0, 500, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maxAllowedBid(&tt.team, tt.minSquad, tt.minPrice); got != tt.want {
+				t.Fatalf("maxAllowedBid() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMinSquadSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  int
+	}{
+		{"unset", nil, 0},
+		{"set", 18, 18},
+		{"stored as JSON", 18.0, 18},
+		{"zero", 0, 0},
+		{"negative", -1, 0},
+		{"fraction", 17.5, 0},
+		{"string", "18", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMinSquadSize(tt.value); got != tt.want {
+				t.Fatalf("parseMinSquadSize(%v) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
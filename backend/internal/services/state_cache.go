@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// The materialised auction state is shared between instances through Redis:
+//   - auction:<id>:state:version  bumped by every change to anything the state shows
+//   - auction:<id>:state:snapshot the last built state and the version it was built at
+const (
+	stateVersionKey  = "state:version"
+	stateSnapshotKey = "state:snapshot"
+
+	// A snapshot is rebuilt at least this often in case a change missed invalidation
+	stateCacheMaxAge = 30 * time.Second
+)
+
+// stateCache is this instance's copy of the materialised state
+type stateCache struct {
+	mu      sync.Mutex // also held while rebuilding, so concurrent readers share one build
+	state   *models.AuctionState
+	version int64
+	builtAt time.Time
+}
+
+// stateSnapshot is the materialised state as stored in Redis
+type stateSnapshot struct {
+	Version int64                `json:"version"`
+	BuiltAt int64                `json:"built_at"` // unix ms
+	State   *models.AuctionState `json:"state"`
+}
+
+// CachedState returns the auction state from the materialised snapshot, rebuilding it
+// with GetState only when something changed since it was built. The countdown and bid
+// freeze move without a change, so they are brought up to date on every call.
+// The returned state is a shallow copy: its slices and pointers must not be modified.
+func (s *AuctionService) CachedState(ctx context.Context) (*models.AuctionState, error) {
+	pipe := s.redis.Pipeline()
+	versionCmd := pipe.Get(ctx, s.key(stateVersionKey))
+	freezeCmd := pipe.Get(ctx, s.key(bidFreezeKey))
+	pipe.Exec(ctx)
+
+	version, err := versionCmd.Int64()
+	if err != nil && err != redis.Nil {
+		// Without Redis there is nothing to tell whether the snapshot is current
+		return s.GetState(ctx)
+	}
+
+	cached, err := s.snapshotAt(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	state := *cached
+	now := time.Now()
+	if state.TimerRunning {
+		state.TimerRemaining = remainingUntil(state.TimerDeadline, now)
+	}
+	freeze, _ := freezeCmd.Int64()
+	state.BidFrozen = freeze > 0 && now.UnixMilli()-freeze < bidFreezeWindow.Milliseconds()
+	return &state, nil
+}
+
+// snapshotAt returns the state built at version: this instance's copy, else the one
+// another instance stored in Redis, else a fresh build that is then shared
+func (s *AuctionService) snapshotAt(ctx context.Context, version int64) (*models.AuctionState, error) {
+	c := &s.stateCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != nil && c.version == version && time.Since(c.builtAt) < stateCacheMaxAge {
+		return c.state, nil
+	}
+
+	// Another instance may already have built this version
+	if data, err := s.redis.Get(ctx, s.key(stateSnapshotKey)).Bytes(); err == nil {
+		var snap stateSnapshot
+		if json.Unmarshal(data, &snap) == nil && snap.State != nil && snap.Version == version {
+			if builtAt := time.UnixMilli(snap.BuiltAt); time.Since(builtAt) < stateCacheMaxAge {
+				c.state, c.version, c.builtAt = snap.State, version, builtAt
+				return c.state, nil
+			}
+		}
+	}
+
+	// The version was read before building, so a change made meanwhile leaves this
+	// snapshot behind the counter and the next reader rebuilds it
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.state, c.version, c.builtAt = state, version, time.Now()
+
+	data, err := json.Marshal(stateSnapshot{Version: version, BuiltAt: c.builtAt.UnixMilli(), State: state})
+	if err == nil {
+		s.redis.Set(ctx, s.key(stateSnapshotKey), data, stateCacheMaxAge)
+	}
+	return state, nil
+}
+
+// InvalidateState marks the materialised state as out of date on every instance.
+// Call it after any change to auctions, bids, teams, players or settings.
+func (s *AuctionService) InvalidateState(ctx context.Context) {
+	if err := s.redis.Incr(ctx, s.key(stateVersionKey)).Err(); err != nil {
+		log.Printf("WARNING: could not invalidate auction state: %v", err)
+		// At least stop this instance serving it
+		s.stateCache.mu.Lock()
+		s.stateCache.state = nil
+		s.stateCache.mu.Unlock()
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// The patch builders below load only the slice of AuctionState a change touches, so a
+// mutation costs one or two queries per patch instead of the full GetState.
+
+// StatusPatch returns the auction status, host switches and timer
+func (s *AuctionService) StatusPatch(ctx context.Context) (*models.StatusPatch, error) {
+	queryCtx, cancel := s.withTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	auction, err := s.repos.Auctions.GetCurrent(queryCtx)
+	if err != nil {
+		return nil, err
+	}
+	timer := s.TimerStatus(ctx)
+	return &models.StatusPatch{
+		Auction:               auction,
+		Status:                auction.Status,
+		BidderBiddingDisabled: s.bidderBiddingDisabled(ctx),
+		TimerRunning:          timer.Running,
+		TimerRemaining:        timer.Remaining,
+		TimerDeadline:         timer.Deadline,
+	}, nil
+}
+
+// LotPatch returns the current lot with its bids and timer
+func (s *AuctionService) LotPatch(ctx context.Context) (*models.LotPatch, error) {
+	queryCtx, cancel := s.withTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	auction, err := s.repos.Auctions.GetCurrent(queryCtx)
+	if err != nil {
+		return nil, err
+	}
+	lot := s.loadLot(queryCtx, auction, s.BidLadder(queryCtx).Max())
+	timer := s.TimerStatus(ctx)
+	lot.TimerRunning = timer.Running
+	lot.TimerRemaining = timer.Remaining
+	lot.TimerDeadline = timer.Deadline
+	lot.BidFrozen = s.bidFrozen(ctx)
+	return lot, nil
+}
+
+// BidPatch returns what an accepted bid changed. Bid history is only read when the bid
+// reached the top of the ladder and tied teams have to be worked out.
+func (s *AuctionService) BidPatch(ctx context.Context, bid *models.Bid) (*models.BidPatch, error) {
+	queryCtx, cancel := s.withTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	auction, err := s.repos.Auctions.GetCurrent(queryCtx)
+	if err != nil {
+		return nil, err
+	}
+	patch := &models.BidPatch{
+		Bid:           bid,
+		Auction:       auction,
+		CurrentBidder: bid.Team,
+		CurrentBid:    auction.CurrentBid,
+	}
+	if maxBid := s.BidLadder(queryCtx).Max(); bid.Amount == maxBid {
+		bids, err := s.repos.Bids.FindByPlayer(queryCtx, bid.PlayerID)
+		if err == nil {
+			patch.TiedTeams = s.tiedTeams(queryCtx, bids, auction.CurrentBid, maxBid)
+		}
+	}
+	timer := s.TimerStatus(ctx)
+	patch.TimerRunning = timer.Running
+	patch.TimerRemaining = timer.Remaining
+	patch.TimerDeadline = timer.Deadline
+	patch.BidFrozen = s.bidFrozen(ctx)
+	return patch, nil
+}
+
+// TeamsPatch returns every team's purse and bidding headroom
+func (s *AuctionService) TeamsPatch(ctx context.Context) (*models.TeamsPatch, error) {
+	queryCtx, cancel := s.withTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	teams, err := s.teamsWithHeadroom(queryCtx, s.BidLadder(queryCtx))
+	if err != nil {
+		return nil, err
+	}
+	patch := &models.TeamsPatch{Teams: make([]models.TeamPurse, 0, len(teams))}
+	for _, team := range teams {
+		patch.Teams = append(patch.Teams, models.TeamPurse{
+			ID:              team.ID,
+			Spent:           team.Spent,
+			RemainingBudget: team.RemainingBudget,
+			PlayerCount:     team.PlayerCount,
+			ForeignCount:    team.ForeignCount,
+			MaxBid:          team.MaxBid,
+			RTMCards:        team.RTMCards,
+		})
+	}
+	return patch, nil
+}
+
+// QueuePatch returns the next players up
+func (s *AuctionService) QueuePatch(ctx context.Context) (*models.QueuePatch, error) {
+	queryCtx, cancel := s.withTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	queue, err := s.repos.Players.GetQueue(queryCtx, 5)
+	if err != nil {
+		return nil, err
+	}
+	return &models.QueuePatch{QueueNext: queue}, nil
+}
+
+// loadLot reads the player under the hammer, the leading bid and the lot's bid history
+func (s *AuctionService) loadLot(ctx context.Context, auction *models.Auction, maxBid int64) *models.LotPatch {
+	lot := &models.LotPatch{Auction: auction}
+
+	// Get current player if any
+	if auction.CurrentPlayerID != nil {
+		player, err := s.repos.Players.FindByID(ctx, *auction.CurrentPlayerID)
+		if err == nil {
+			lot.CurrentPlayer = player
+
+			// Set current_bid for frontend: use actual bid if exists, otherwise base_price
+			if auction.CurrentBid != nil {
+				lot.CurrentBid = auction.CurrentBid
+			} else {
+				lot.CurrentBid = &player.BasePrice
+			}
+		}
+	}
+
+	// Get current bidder if any
+	if auction.CurrentBidderID != nil {
+		team, err := s.repos.Teams.FindByID(ctx, *auction.CurrentBidderID)
+		if err == nil {
+			lot.CurrentBidder = team
+		}
+	}
+
+	// Get bid history for current player (only this player's bids)
+	if auction.CurrentPlayerID != nil {
+		bids, err := s.repos.Bids.FindByPlayer(ctx, *auction.CurrentPlayerID)
+		if err == nil {
+			lot.BidHistory = bids
+			lot.TiedTeams = s.tiedTeams(ctx, bids, auction.CurrentBid, maxBid)
+		}
+	}
+
+	lot.RTM = s.loadRTM(ctx, auction, lot)
+	return lot
+}
+
+// tiedTeams returns the teams that bid the top of the ladder when more than one did
+func (s *AuctionService) tiedTeams(ctx context.Context, bids []models.Bid, currentBid *int64, maxBid int64) []models.Team {
+	if currentBid == nil || *currentBid != maxBid {
+		return nil
+	}
+	// Find all unique teams that bid the max
+	teamIDs := make(map[uuid.UUID]bool)
+	for _, bid := range bids {
+		if bid.Amount == maxBid {
+			teamIDs[bid.TeamID] = true
+		}
+	}
+	// If more than 1 team bid the max, it's a tie
+	if len(teamIDs) < 2 {
+		return nil
+	}
+	var tied []models.Team
+	for teamID := range teamIDs {
+		team, err := s.repos.Teams.FindByID(ctx, teamID)
+		if err == nil && team != nil {
+			tied = append(tied, *team)
+		}
+	}
+	return tied
+}
+
+// teamsWithHeadroom returns all teams with the most each can bid right now and the
+// right-to-match cards they have left
+func (s *AuctionService) teamsWithHeadroom(ctx context.Context, ladder BidLadder) ([]models.Team, error) {
+	teams, err := s.repos.Teams.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	minSquad := s.minSquadSize(ctx)
+	minPrice := s.minPlayerPrice(ctx, ladder)
+	cards, err := s.rtmCardsLeft(ctx, s.repos, teams)
+	if err != nil {
+		return nil, err
+	}
+	for i := range teams {
+		teams[i].MaxBid = maxAllowedBid(&teams[i], minSquad, minPrice)
+		teams[i].RTMCards = cards[teams[i].ID]
+	}
+	return teams, nil
+}
+
+// bidFrozen reports whether bidding is inside the freeze window after the last bid
+func (s *AuctionService) bidFrozen(ctx context.Context) bool {
+	freeze, _ := s.redis.Get(ctx, s.key(bidFreezeKey)).Int64()
+	now := time.Now().UnixMilli()
+	return freeze > 0 && now-freeze < bidFreezeWindow.Milliseconds()
+}
+
+// bidderBiddingDisabled reports whether the host has paused bidding from bidder devices
+func (s *AuctionService) bidderBiddingDisabled(ctx context.Context) bool {
+	disabled, _ := s.redis.Get(ctx, s.key(bidderBiddingDisabledKey)).Bool()
+	return disabled
+}
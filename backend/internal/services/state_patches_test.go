@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/auctionapp/backend/internal/models"
+)
+
+// TestPatchPayloads compares the size of each patch with the full state it replaces
+// during a typical lot: 10 teams, 20 players in the pool and a few bids in.
+func TestPatchPayloads(t *testing.T) {
+	svc, repos := newTestAuctionService(t)
+	ctx := context.Background()
+
+	var teams []*models.Team
+	for i := 0; i < 10; i++ {
+		team := &models.Team{
+			Name:       fmt.Sprintf("Team %d", i),
+			ShortName:  fmt.Sprintf("T%d", i),
+			Color:      "#000000",
+			Budget:     10_000_000,
+			MaxPlayers: 25,
+			MaxForeign: 8,
+		}
+		if err := repos.Teams.Create(ctx, team); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		teams = append(teams, team)
+	}
+
+	ladder := svc.BidLadder(ctx)
+	var players []*models.Player
+	for i := 0; i < 20; i++ {
+		player := &models.Player{
+			Name:      fmt.Sprintf("Player %d", i),
+			Country:   "India",
+			Role:      "Batsman",
+			BasePrice: ladder[0],
+			Category:  "Set 1",
+			Stats:     map[string]interface{}{"matches": 50, "runs": 1500},
+		}
+		if err := repos.Players.Create(ctx, player); err != nil {
+			t.Fatalf("create player: %v", err)
+		}
+		players = append(players, player)
+	}
+
+	if _, err := svc.StartAuction(ctx); err != nil {
+		t.Fatalf("start auction: %v", err)
+	}
+	if _, err := svc.StartBidForPlayer(ctx, players[0].ID); err != nil {
+		t.Fatalf("start player: %v", err)
+	}
+	var bid *models.Bid
+	for round := 0; round < 4 && round < len(ladder); round++ {
+		var err error
+		bid, err = svc.PlaceBid(ctx, teams[round%2].ID, ladder[round], true, true)
+		if err != nil {
+			t.Fatalf("bid %d: %v", round, err)
+		}
+	}
+
+	state, err := svc.GetState(ctx)
+	if err != nil {
+		t.Fatalf("get state: %v", err)
+	}
+	stateSize := jsonSize(t, state)
+
+	bidPatch, err := svc.BidPatch(ctx, bid)
+	if err != nil {
+		t.Fatalf("bid patch: %v", err)
+	}
+	statusPatch, err := svc.StatusPatch(ctx)
+	if err != nil {
+		t.Fatalf("status patch: %v", err)
+	}
+	teamsPatch, err := svc.TeamsPatch(ctx)
+	if err != nil {
+		t.Fatalf("teams patch: %v", err)
+	}
+
+	for name, patch := range map[string]interface{}{
+		"patch:bid-added":   bidPatch,
+		"patch:status":      statusPatch,
+		"patch:team-purses": teamsPatch,
+	} {
+		size := jsonSize(t, patch)
+		t.Logf("%s: %d bytes, %.0f%% of auction:state (%d bytes)", name, size, 100*float64(size)/float64(stateSize), stateSize)
+		if size >= stateSize/2 {
+			t.Errorf("%s is %d bytes, want under half of the %d byte state", name, size, stateSize)
+		}
+	}
+}
+
+func jsonSize(t *testing.T, v interface{}) int {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return len(data)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/auctionapp/backend/internal/models"
 	"github.com/auctionapp/backend/internal/repository"
@@ -9,11 +10,25 @@ import (
 	"github.com/google/uuid"
 )
 
-// UserService handles user operations
+// UserService handles user operations. One bound to an organisation (see
+// Services.ForOrganization) only sees and creates that organisation's users.
 type UserService struct {
 	repos *repository.Repositories
 }
 
+// assignableRoles are the roles users can be given through the API. Super admins run the
+// platform and are not part of any organisation, so they cannot be created this way.
+var assignableRoles = map[string]bool{
+	"org_admin": true,
+	"admin":     true,
+	"host":      true,
+	"bidder":    true,
+	"viewer":    true,
+}
+
+// ErrUserNotFound is returned for a user that does not exist in the organisation
+var ErrUserNotFound = errors.New("user not found")
+
 // NewUserService creates a new user service
 func NewUserService(repos *repository.Repositories) *UserService {
 	return &UserService{repos: repos}
@@ -42,12 +57,27 @@ func (s *UserService) Create(ctx context.Context, req models.CreateUserRequest)
 		return nil, err
 	}
 
+	if !assignableRoles[req.Role] {
+		return nil, errors.New("role must be one of org_admin, admin, host, bidder or viewer")
+	}
+
 	user := &models.User{
-		Email:        req.Email,
-		PasswordHash: hash,
-		Name:         req.Name,
-		Role:         req.Role,
-		Status:       "active",
+		Email:          req.Email,
+		PasswordHash:   hash,
+		Name:           req.Name,
+		Role:           req.Role,
+		Status:         "active",
+		OrganizationID: s.repos.OrganizationID(),
+	}
+	if user.OrganizationID == nil {
+		orgID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return nil, errors.New("organization_id is required")
+		}
+		if _, err := s.repos.Organizations.FindByID(ctx, orgID); err != nil {
+			return nil, ErrOrganizationNotFound
+		}
+		user.OrganizationID = &orgID
 	}
 
 	if req.TeamID != "" {
@@ -56,6 +86,9 @@ func (s *UserService) Create(ctx context.Context, req models.CreateUserRequest)
 			user.TeamID = &teamID
 		}
 	}
+	if err := s.checkTeam(ctx, user); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Users.Create(ctx, user); err != nil {
 		return nil, err
@@ -68,7 +101,7 @@ func (s *UserService) Create(ctx context.Context, req models.CreateUserRequest)
 func (s *UserService) Update(ctx context.Context, id uuid.UUID, req models.UpdateUserRequest) (*models.User, error) {
 	user, err := s.repos.Users.FindByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, ErrUserNotFound
 	}
 
 	if req.Email != nil {
@@ -78,6 +111,9 @@ func (s *UserService) Update(ctx context.Context, id uuid.UUID, req models.Updat
 		user.Name = *req.Name
 	}
 	if req.Role != nil {
+		if !assignableRoles[*req.Role] {
+			return nil, errors.New("role must be one of org_admin, admin, host, bidder or viewer")
+		}
 		user.Role = *req.Role
 	}
 	if req.Status != nil {
@@ -93,6 +129,9 @@ func (s *UserService) Update(ctx context.Context, id uuid.UUID, req models.Updat
 			}
 		}
 	}
+	if err := s.checkTeam(ctx, user); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Users.Update(ctx, user); err != nil {
 		return nil, err
@@ -103,5 +142,23 @@ func (s *UserService) Update(ctx context.Context, id uuid.UUID, req models.Updat
 
 // Delete deletes a user
 func (s *UserService) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.repos.Users.FindByID(ctx, id); err != nil {
+		return ErrUserNotFound
+	}
 	return s.repos.Users.Delete(ctx, id)
 }
+
+// checkTeam makes sure a user's team belongs to one of the user's organisation's auctions
+func (s *UserService) checkTeam(ctx context.Context, user *models.User) error {
+	if user.TeamID == nil || user.OrganizationID == nil {
+		return nil
+	}
+	auctionID, err := s.repos.Teams.AuctionOf(ctx, *user.TeamID)
+	if err != nil {
+		return errors.New("team not found")
+	}
+	if _, err := s.repos.ForOrganization(*user.OrganizationID).Auctions.FindByID(ctx, auctionID); err != nil {
+		return errors.New("team not found")
+	}
+	return nil
+}
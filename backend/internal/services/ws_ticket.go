@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// WebSocket tickets let a browser open /ws without putting its access token in the URL,
+// where proxies and the request logger would record it
+const (
+	wsTicketPrefix = "ws:ticket:"
+
+	// WSTicketTTL is how long a ticket can be redeemed for
+	WSTicketTTL = 30 * time.Second
+)
+
+// wsTicket is what a ticket stands for, as stored in Redis
+type wsTicket struct {
+	Token  string `json:"token"`
+	Origin string `json:"origin"`
+}
+
+// IssueWSTicket returns a single-use ticket that opens /ws as the holder of accessToken.
+// It is valid for WSTicketTTL and only from the origin it was issued to.
+func (s *AuthService) IssueWSTicket(ctx context.Context, accessToken, origin string) (string, error) {
+	if s.redis == nil {
+		return "", errors.New("WebSocket tickets are unavailable")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.New("failed to generate ticket")
+	}
+	ticket := hex.EncodeToString(raw)
+
+	data, err := json.Marshal(wsTicket{Token: accessToken, Origin: origin})
+	if err != nil {
+		return "", err
+	}
+	if err := s.redis.Set(ctx, wsTicketPrefix+ticket, data, WSTicketTTL).Err(); err != nil {
+		return "", errors.New("failed to store ticket")
+	}
+	return ticket, nil
+}
+
+// RedeemWSTicket exchanges a ticket for the access token it was issued for. The ticket
+// is deleted on first use, even when the origin does not match.
+func (s *AuthService) RedeemWSTicket(ctx context.Context, ticket, origin string) (string, error) {
+	if s.redis == nil || ticket == "" {
+		return "", errors.New("invalid or expired ticket")
+	}
+
+	data, err := s.redis.GetDel(ctx, wsTicketPrefix+ticket).Bytes()
+	if err != nil {
+		return "", errors.New("invalid or expired ticket")
+	}
+	var t wsTicket
+	if err := json.Unmarshal(data, &t); err != nil {
+		return "", errors.New("invalid or expired ticket")
+	}
+	if t.Origin != origin {
+		return "", errors.New("ticket was issued to another origin")
+	}
+	return t.Token, nil
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// countryCodes maps lower-cased cricketing country names to their ISO 3166 codes
+var countryCodes = map[string]string{
+	"afghanistan":          "AF",
+	"australia":            "AU",
+	"bangladesh":           "BD",
+	"canada":               "CA",
+	"india":                "IN",
+	"ireland":              "IE",
+	"namibia":              "NA",
+	"nepal":                "NP",
+	"netherlands":          "NL",
+	"new zealand":          "NZ",
+	"oman":                 "OM",
+	"pakistan":             "PK",
+	"south africa":         "ZA",
+	"sri lanka":            "LK",
+	"uae":                  "AE",
+	"united arab emirates": "AE",
+	"united states":        "US",
+	"usa":                  "US",
+	"zimbabwe":             "ZW",
+}
+
+// subdivisionFlags are the flags of home nations that have no ISO country code
+var subdivisionFlags = map[string]string{
+	"england":  "\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F",
+	"scotland": "\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F",
+	"wales":    "\U0001F3F4\U000E0067\U000E0062\U000E0077\U000E006C\U000E0073\U000E007F",
+}
+
+// CountryFlag returns the flag emoji of a country name, or "" when it is not known
+func CountryFlag(country string) string {
+	name := strings.ToLower(strings.TrimSpace(country))
+	if flag, ok := subdivisionFlags[name]; ok {
+		return flag
+	}
+	code, ok := countryCodes[name]
+	if !ok {
+		return ""
+	}
+	// A flag is the code's two letters as regional indicator symbols
+	var flag strings.Builder
+	for _, c := range code {
+		flag.WriteRune(0x1F1E6 + c - 'A')
+	}
+	return flag.String()
+}
+
+// CountryFlags returns every known country name with its flag emoji, sorted by name
+func CountryFlags() [][2]string {
+	names := make([]string, 0, len(countryCodes)+len(subdivisionFlags))
+	for name := range countryCodes {
+		names = append(names, name)
+	}
+	for name := range subdivisionFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flags := make([][2]string, len(names))
+	for i, name := range names {
+		flags[i] = [2]string{name, CountryFlag(name)}
+	}
+	return flags
+}
+
+// SameCountry reports whether a player's country is the given country, either of them
+// written as its name (in any case) or as its flag emoji
+func SameCountry(country, home string) bool {
+	return countryKey(country) == countryKey(home)
+}
+
+// countryKey is the form countries are compared in: the flag emoji of a known country,
+// else the lower-cased name. A flag is its own key.
+func countryKey(country string) string {
+	if flag := CountryFlag(country); flag != "" {
+		return flag
+	}
+	return strings.ToLower(strings.TrimSpace(country))
+}
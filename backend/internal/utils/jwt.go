@@ -14,6 +14,7 @@ type JWTClaims struct {
 	Email  string     `json:"email"`
 	Role   string     `json:"role"`
 	TeamID *uuid.UUID `json:"team_id,omitempty"`
+	OrgID  *uuid.UUID `json:"org_id,omitempty"` // the user's organisation; nil for platform super admins
 	jwt.RegisteredClaims
 }
 
@@ -26,7 +27,7 @@ func GetTokenExpirations(role string) (accessMinutes int, refreshMinutes int) {
 	case "host":
 		// Host: 6 hrs idle, 24 hrs max
 		return 6 * 60, 24 * 60
-	case "admin", "super_admin":
+	case "admin", "org_admin", "super_admin":
 		// Admin: 30 min idle, 8 hrs max
 		return 30, 8 * 60
 	default:
@@ -36,12 +37,13 @@ func GetTokenExpirations(role string) (accessMinutes int, refreshMinutes int) {
 }
 
 // GenerateJWT generates a new JWT token
-func GenerateJWT(userID uuid.UUID, email, role string, teamID *uuid.UUID, secret string, expiryMinutes int) (string, error) {
+func GenerateJWT(userID uuid.UUID, email, role string, teamID, orgID *uuid.UUID, secret string, expiryMinutes int) (string, error) {
 	claims := JWTClaims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
 		TeamID: teamID,
+		OrgID:  orgID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expiryMinutes) * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadSpreadsheet reads every row of a CSV or XLSX file, choosing the format from the file extension.
+// For XLSX only the first sheet is read.
+func ReadSpreadsheet(filename string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(r)
+	case ".xlsx":
+		return readXLSX(r)
+	default:
+		return nil, errors.New("unsupported file type - upload a .csv or .xlsx file")
+	}
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// Excel writes a UTF-8 byte order mark at the start of CSV files
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid CSV: " + err.Error())
+	}
+	return rows, nil
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("XLSX file has no sheets")
+	}
+	return f.GetRows(sheets[0])
+}
+
+// Sheet is a named table of cells for export. Numbers are kept numeric in XLSX.
+type Sheet struct {
+	Name string
+	Rows [][]interface{}
+}
+
+// WriteCSV writes one sheet as CSV
+func WriteCSV(w io.Writer, sheet Sheet) error {
+	writer := csv.NewWriter(w)
+	for _, row := range sheet.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell != nil {
+				record[i] = fmt.Sprint(cell)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteXLSX writes sheets to an XLSX workbook, one worksheet each, with a bold first row
+func WriteXLSX(w io.Writer, sheets []Sheet) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		name := xlsxSheetName(sheet.Name, used)
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			return err
+		}
+
+		for r, row := range sheet.Rows {
+			cell, err := excelize.CoordinatesToCellName(1, r+1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				return err
+			}
+		}
+		if len(sheet.Rows) > 0 && len(sheet.Rows[0]) > 0 {
+			last, _ := excelize.CoordinatesToCellName(len(sheet.Rows[0]), 1)
+			if err := f.SetCellStyle(name, "A1", last, bold); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+// xlsxSheetName makes a unique worksheet name within Excel's limits (31 chars, no []:*?/\)
+func xlsxSheetName(name string, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "Sheet"
+	}
+	if len([]rune(name)) > 31 {
+		name = string([]rune(name)[:31])
+	}
+
+	candidate := name
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		base := []rune(name)
+		if len(base)+len(suffix) > 31 {
+			base = base[:31-len(suffix)]
+		}
+		candidate = string(base) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/auctionapp/backend/internal/services"
@@ -30,16 +31,47 @@ type Client struct {
 	UserID uuid.UUID
 	Role   string
 	TeamID *uuid.UUID
+
+	// The auction the client follows; it only receives that auction's events
+	AuctionID uuid.UUID
+
+	// Patches is set for clients that connected with ?patches=1: they apply patch:*
+	// events to their snapshot instead of receiving auction:state on every change
+	Patches bool
+
+	// Connection details for admins; ID and ConnectedAt are set when the hub registers it
+	ID          uuid.UUID
+	RemoteIP    string
+	ConnectedAt time.Time
+
+	// Why an admin disconnected the client, set by the hub before it closes send
+	closeReason string
+
+	// The access token an authenticated socket was opened with. The socket is closed
+	// when the token is revoked or expires.
+	token     string
+	expiresAt time.Time
 }
 
-// HandleWebSocket handles a new WebSocket connection
+// HandleWebSocket handles a new WebSocket connection. Browsers authenticate with a
+// ?ticket= from POST /api/auth/ws-ticket, or with the access_token cookie. The socket
+// follows the auction named by ?auction=, else the bidder's team's auction, else the default.
 func HandleWebSocket(c *websocket.Conn, hub *Hub, svc *services.Services, jwtSecret string) {
-	// Extract token from query params or cookie
-	token := c.Query("token")
-	if token == "" {
+	ctx := context.Background()
+
+	var token string
+	if ticket := c.Query("ticket"); ticket != "" {
+		var err error
+		token, err = svc.Auth.RedeemWSTicket(ctx, ticket, c.Headers("Origin"))
+		if err != nil {
+			c.WriteMessage(websocket.TextMessage, []byte(`{"error":"Invalid ticket"}`))
+			c.Close()
+			return
+		}
+	} else {
 		token = c.Cookies("access_token")
 	}
-	
+
 	if token == "" {
 		c.WriteMessage(websocket.TextMessage, []byte(`{"error":"Missing token"}`))
 		c.Close()
@@ -53,65 +85,164 @@ func HandleWebSocket(c *websocket.Conn, hub *Hub, svc *services.Services, jwtSec
 		c.Close()
 		return
 	}
+	if revoked, _ := svc.Auth.IsTokenBlacklisted(ctx, token); revoked {
+		c.WriteMessage(websocket.TextMessage, []byte(`{"error":"Token has been revoked"}`))
+		c.Close()
+		return
+	}
+	// Suspended and deleted users keep a valid token until it expires
+	if !svc.Auth.IsUserActive(ctx, claims.UserID) {
+		c.WriteMessage(websocket.TextMessage, []byte(`{"error":"Account is not active"}`))
+		c.Close()
+		return
+	}
 
-	client := &Client{
-		conn:   c,
-		send:   make(chan []byte, 256),
-		hub:    hub,
-		UserID: claims.UserID,
-		Role:   claims.Role,
-		TeamID: claims.TeamID,
+	hub, svc, ok := forAuction(c, hub, svc.ForOrganization(claims.OrgID), claims.TeamID)
+	if !ok {
+		return
 	}
 
-	hub.register <- client
+	client := &Client{
+		conn:    c,
+		send:    make(chan []byte, 256),
+		hub:     hub,
+		UserID:  claims.UserID,
+		Role:    claims.Role,
+		TeamID:  claims.TeamID,
+		Patches: c.Query("patches") == "1",
 
-	// Send initial state
-	ctx := context.Background()
-	state, err := svc.Auction.GetState(ctx)
-	if err == nil {
-		initialState, _ := json.Marshal(map[string]interface{}{
-			"event": "auction:state",
-			"data":  state,
-		})
-		client.send <- initialState
+		RemoteIP: remoteIP(c),
+
+		token: token,
+	}
+	if claims.ExpiresAt != nil {
+		client.expiresAt = claims.ExpiresAt.Time
+	}
+
+	if !client.join(hub, svc, c.Query("since")) {
+		return
 	}
 
 	// Start goroutines for reading and writing
-	go client.writePump()
+	go client.writePump(svc)
 	client.readPump(svc)
 }
 
 // HandlePublicWebSocket handles a new public WebSocket connection (no auth required)
+// following the auction named by ?auction=, else the default auction
 func HandlePublicWebSocket(c *websocket.Conn, hub *Hub, svc *services.Services) {
+	hub, svc, ok := forAuction(c, hub, svc, nil)
+	if !ok {
+		return
+	}
+
 	client := &Client{
-		conn:   c,
-		send:   make(chan []byte, 256),
-		hub:    hub,
-		UserID: uuid.Nil,
-		Role:   "viewer",
-		TeamID: nil,
+		conn:    c,
+		send:    make(chan []byte, 256),
+		hub:     hub,
+		UserID:  uuid.Nil,
+		Role:    "viewer",
+		TeamID:  nil,
+		Patches: c.Query("patches") == "1",
+
+		RemoteIP: remoteIP(c),
+	}
+
+	if !client.join(hub, svc, c.Query("since")) {
+		return
 	}
 
-	hub.register <- client
+	// Start goroutines for reading and writing
+	go client.writePump(svc)
+	client.readPumpPublic(svc)
+}
 
-	// Send initial state
+// forAuction returns the hub and services of the auction a socket follows, or closes the
+// socket if the auction does not exist (in the organisation svc is limited to)
+func forAuction(c *websocket.Conn, hub *Hub, svc *services.Services, teamID *uuid.UUID) (*Hub, *services.Services, bool) {
+	auction, err := svc.Auctions.Resolve(context.Background(), c.Query("auction"), teamID)
+	if err != nil {
+		c.WriteMessage(websocket.TextMessage, []byte(`{"error":"Auction not found"}`))
+		c.Close()
+		return nil, nil, false
+	}
+	return hub.ForAuction(auction.ID), svc.ForAuction(auction.ID), true
+}
+
+// join registers the client with the hub. A reconnecting client passes ?since=<seq>, the
+// seq before the lowest one it has not seen, and is sent the events after it (some it may
+// already have); otherwise, or when the gap is too large, it gets a full auction:state
+// snapshot. Clients can also ask for one at any time with state:request.
+// Returns false if the connection was rejected.
+func (c *Client) join(hub *Hub, svc *services.Services, sinceParam string) bool {
+	accepted, resumed := hub.Register(c, parseSince(sinceParam))
+	if !accepted {
+		c.conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"Server is at capacity"}`))
+		c.conn.Close()
+		return false
+	}
+	if !resumed {
+		c.sendSnapshot(hub, svc)
+	}
+	return true
+}
+
+// remoteIP returns the address the upgrade request came from, which the upgrade
+// middleware stores in the "ip" local
+func remoteIP(c *websocket.Conn) string {
+	ip, _ := c.Locals("ip").(string)
+	return ip
+}
+
+// parseSince reads the sequence number a client resumes after, or -1 for a fresh connection
+func parseSince(param string) int64 {
+	if param == "" {
+		return -1
+	}
+	n, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// sendSnapshot sends the full auction state, stamped with the latest sequence number
+// so the client knows which later events to apply on top of it. Hosts and admins also get presence.
+func (c *Client) sendSnapshot(hub *Hub, svc *services.Services) {
+	seq := hub.LastSeq()
 	ctx := context.Background()
-	state, err := svc.Auction.GetState(ctx)
-	if err == nil {
-		initialState, _ := json.Marshal(map[string]interface{}{
-			"event": "auction:state",
-			"data":  state,
-		})
-		client.send <- initialState
+	state, err := svc.Auction.CachedState(ctx)
+	if err != nil {
+		return
 	}
+	state = hub.HostView(ctx, state, c.Role)
+	snapshot, err := json.Marshal(map[string]interface{}{
+		"event": "auction:state",
+		"seq":   seq,
+		"data":  state,
+	})
+	if err != nil {
+		return
+	}
+	c.trySend(snapshot)
+}
 
-	// Start goroutines for reading and writing
-	go client.writePump()
-	client.readPumpPublic()
+// trySend queues a frame without blocking; the hub closes send when it drops a slow client
+func (c *Client) trySend(message []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Dropping message for %s: client closed", c.UserID)
+		}
+	}()
+	select {
+	case c.send <- message:
+	default:
+		log.Printf("Dropping message for %s: send buffer full", c.UserID)
+	}
 }
 
 // readPumpPublic pumps messages from the public WebSocket connection (read-only)
-func (c *Client) readPumpPublic() {
+func (c *Client) readPumpPublic(svc *services.Services) {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
@@ -144,9 +275,13 @@ func (c *Client) readPumpPublic() {
 			continue
 		}
 
-		// Only handle ping for public clients (read-only)
-		if msg.Event == "ping" {
-			c.send <- []byte(`{"event":"pong"}`)
+		// Only handle ping and snapshot requests for public clients (read-only)
+		switch msg.Event {
+		case "ping":
+			c.trySend([]byte(`{"event":"pong"}`))
+
+		case "state:request":
+			c.sendSnapshot(c.hub, svc)
 		}
 	}
 }
@@ -178,8 +313,9 @@ func (c *Client) readPump(svc *services.Services) {
 
 		// Parse message
 		var msg struct {
-			Event string          `json:"event"`
-			Data  json.RawMessage `json:"data"`
+			Event     string          `json:"event"`
+			Data      json.RawMessage `json:"data"`
+			RequestID string          `json:"request_id,omitempty"`
 		}
 		if err := json.Unmarshal(message, &msg); err != nil {
 			continue
@@ -188,22 +324,16 @@ func (c *Client) readPump(svc *services.Services) {
 		// Handle client events
 		switch msg.Event {
 		case "ping":
-			c.send <- []byte(`{"event":"pong"}`)
+			c.trySend([]byte(`{"event":"pong"}`))
+
+		case "state:request":
+			c.sendSnapshot(c.hub, svc)
 
 		case "bid:place":
-			if c.Role != "bidder" || c.TeamID == nil {
-				c.send <- []byte(`{"event":"error","data":{"message":"Not authorized to bid"}}`)
-				continue
-			}
-			var bidData struct {
-				Amount int64 `json:"amount"`
-			}
-			if err := json.Unmarshal(msg.Data, &bidData); err != nil {
-				continue
-			}
-			// Place bid through service
-			// This would need context - simplified here
-			log.Printf("Bid placed by team %s: %d", c.TeamID.String(), bidData.Amount)
+			c.placeBid(svc, msg.RequestID, msg.Data)
+
+		case "rtm:respond":
+			c.respondRTM(svc, msg.RequestID, msg.Data)
 
 		default:
 			log.Printf("Unknown event: %s", msg.Event)
@@ -211,9 +341,102 @@ func (c *Client) readPump(svc *services.Services) {
 	}
 }
 
-// writePump pumps messages to the WebSocket connection
-func (c *Client) writePump() {
+// placeBid places a bid for the client's team and replies with a bid:ack or bid:error
+// frame carrying the client's request_id. Accepted bids are broadcast like the REST path.
+func (c *Client) placeBid(svc *services.Services, requestID string, data json.RawMessage) {
+	if c.Role != "bidder" || c.TeamID == nil {
+		c.reply("bid:error", requestID, map[string]string{"message": "Not authorized to bid"})
+		return
+	}
+
+	var bidData struct {
+		Amount    int64  `json:"amount"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(data, &bidData); err != nil || bidData.Amount <= 0 {
+		c.reply("bid:error", requestID, map[string]string{"message": "Invalid bid"})
+		return
+	}
+	// The request ID may also be sent inside data
+	if requestID == "" {
+		requestID = bidData.RequestID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bid, err := svc.Auction.PlaceBid(ctx, *c.TeamID, bidData.Amount, false, false)
+	if err != nil {
+		c.reply("bid:error", requestID, map[string]string{"message": err.Error()})
+		return
+	}
+
+	c.reply("bid:ack", requestID, bid)
+
+	// Broadcast bid to all clients
+	c.hub.BroadcastBid(ctx, svc, bid)
+}
+
+// respondRTM answers the right-to-match window open for the client's team and replies
+// with an rtm:ack or rtm:error frame. The sale it makes is broadcast like the REST path.
+func (c *Client) respondRTM(svc *services.Services, requestID string, data json.RawMessage) {
+	if c.Role != "bidder" || c.TeamID == nil {
+		c.reply("rtm:error", requestID, map[string]string{"message": "Not authorized to answer a right to match"})
+		return
+	}
+
+	var rtmData struct {
+		Accept    bool   `json:"accept"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(data, &rtmData); err != nil {
+		c.reply("rtm:error", requestID, map[string]string{"message": "Invalid response"})
+		return
+	}
+	if requestID == "" {
+		requestID = rtmData.RequestID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := svc.Auction.RespondRTM(ctx, c.TeamID, rtmData.Accept)
+	if err != nil {
+		c.reply("rtm:error", requestID, map[string]string{"message": err.Error()})
+		return
+	}
+
+	c.reply("rtm:ack", requestID, result)
+	c.hub.BroadcastRTMResult(ctx, svc, result)
+}
+
+// reply sends a frame to this client only, correlated by the client's request ID
+func (c *Client) reply(event, requestID string, data interface{}) {
+	frame := map[string]interface{}{
+		"event": event,
+		"data":  data,
+	}
+	if requestID != "" {
+		frame["request_id"] = requestID
+	}
+	jsonData, err := json.Marshal(frame)
+	if err != nil {
+		log.Printf("Error marshaling reply: %v", err)
+		return
+	}
+	c.trySend(jsonData)
+}
+
+// writePump pumps messages to the WebSocket connection. Authenticated sockets are
+// closed when their token expires, and checked against the blacklist on every ping.
+func (c *Client) writePump(svc *services.Services) {
 	ticker := time.NewTicker(pingPeriod)
+	var expired <-chan time.Time
+	if !c.expiresAt.IsZero() {
+		expiry := time.NewTimer(time.Until(c.expiresAt))
+		defer expiry.Stop()
+		expired = expiry.C
+	}
 	defer func() {
 		ticker.Stop()
 		c.conn.Close()
@@ -224,7 +447,7 @@ func (c *Client) writePump() {
 		case message, ok := <-c.send:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.conn.WriteMessage(websocket.CloseMessage, c.closeMessage())
 				return
 			}
 
@@ -233,11 +456,28 @@ func (c *Client) writePump() {
 			}
 
 		case <-ticker.C:
+			if c.token != "" {
+				if revoked, _ := svc.Auth.IsTokenBlacklisted(context.Background(), c.token); revoked {
+					c.endSession("Session revoked")
+					return
+				}
+			}
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			// Send ping to keep connection alive
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
+
+		case <-expired:
+			c.endSession("Session expired")
+			return
 		}
 	}
 }
+
+// endSession sends the close frame for a socket whose token is no longer valid.
+// The client should sign in again (or refresh) and reconnect.
+func (c *Client) endSession(reason string) {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason))
+}
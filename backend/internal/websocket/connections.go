@@ -0,0 +1,241 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Admin actions reach every node as control messages on the fan-out channel. Each node
+// answers by pushing its result onto the list named in reply_to.
+const (
+	controlReplyPrefix = "auction:ws:reply:"
+	controlReplyWait   = time.Second // how long to wait for each node's answer
+	controlReplyTTL    = 10 * time.Second
+)
+
+// maxCloseReason is the longest reason a close frame can carry (125 bytes less the code)
+const maxCloseReason = 123
+
+// fanoutControl is an admin action relayed to the other nodes
+type fanoutControl struct {
+	Action   string     `json:"action"` // "list" or "disconnect"
+	ReplyTo  string     `json:"reply_to"`
+	ClientID *uuid.UUID `json:"client_id,omitempty"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Reason   string     `json:"reason,omitempty"`
+}
+
+// Connections lists the clients connected to every node, oldest first
+func (h *Hub) Connections(ctx context.Context) []models.ConnectionInfo {
+	answers := h.askNodes(ctx, fanoutControl{Action: "list"})
+	connections := []models.ConnectionInfo{}
+	for _, answer := range answers {
+		var node []models.ConnectionInfo
+		if json.Unmarshal(answer, &node) == nil {
+			connections = append(connections, node...)
+		}
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].ConnectedAt.Before(connections[j].ConnectedAt)
+	})
+	return connections
+}
+
+// DisconnectClient closes one connection, on whichever node holds it, with a close frame
+// carrying reason. Returns false if no node has the connection.
+func (h *Hub) DisconnectClient(ctx context.Context, clientID uuid.UUID, reason string) bool {
+	return h.disconnect(ctx, fanoutControl{Action: "disconnect", ClientID: &clientID, Reason: reason}) > 0
+}
+
+// DisconnectUser closes every connection of a user across all nodes and returns how many were closed
+func (h *Hub) DisconnectUser(ctx context.Context, userID uuid.UUID, reason string) int {
+	return h.disconnect(ctx, fanoutControl{Action: "disconnect", UserID: &userID, Reason: reason})
+}
+
+// disconnect runs a disconnect on every node and totals the connections closed
+func (h *Hub) disconnect(ctx context.Context, ctl fanoutControl) int {
+	ctl.Reason = truncateReason(ctl.Reason)
+	closed := 0
+	for _, answer := range h.askNodes(ctx, ctl) {
+		n, _ := strconv.Atoi(string(answer))
+		closed += n
+	}
+	return closed
+}
+
+// askNodes runs a control action on this node and every other live node, and returns
+// each node's JSON answer. Nodes that do not answer in time are left out.
+func (h *Hub) askNodes(ctx context.Context, ctl fanoutControl) []json.RawMessage {
+	answers := []json.RawMessage{h.runControl(&ctl)}
+	if h.redis == nil {
+		return answers
+	}
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	nodes, err := h.redis.ZRangeByScore(ctx, presenceNodesKey, &redis.ZRangeBy{Min: now, Max: "+inf"}).Result()
+	if err != nil {
+		log.Printf("WARNING: could not list nodes: %v", err)
+		return answers
+	}
+	others := 0
+	for _, node := range nodes {
+		if node != h.nodeID {
+			others++
+		}
+	}
+	if others == 0 {
+		return answers
+	}
+
+	ctl.ReplyTo = controlReplyPrefix + uuid.NewString()
+	payload, err := json.Marshal(fanoutMessage{Node: h.nodeID, Control: &ctl})
+	if err != nil {
+		return answers
+	}
+	if err := h.redis.Publish(ctx, fanoutChannel, payload).Err(); err != nil {
+		log.Printf("WARNING: control fan-out publish failed: %v", err)
+		return answers
+	}
+	defer h.redis.Del(context.Background(), ctl.ReplyTo)
+
+	for i := 0; i < others; i++ {
+		reply, err := h.redis.BLPop(ctx, controlReplyWait, ctl.ReplyTo).Result()
+		if err != nil {
+			// A node went away without answering
+			break
+		}
+		answers = append(answers, json.RawMessage(reply[1]))
+	}
+	return answers
+}
+
+// handleControl runs a control action relayed from another node and pushes the answer back
+func (h *Hub) handleControl(ctl *fanoutControl) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Error in hub control operation: %v", r)
+		}
+	}()
+	answer := h.runControl(ctl)
+	if ctl.ReplyTo == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+	defer cancel()
+	pipe := h.redis.TxPipeline()
+	pipe.RPush(ctx, ctl.ReplyTo, []byte(answer))
+	pipe.Expire(ctx, ctl.ReplyTo, controlReplyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("WARNING: could not answer control message: %v", err)
+	}
+}
+
+// runControl runs a control action against this node's clients and returns its JSON answer
+func (h *Hub) runControl(ctl *fanoutControl) json.RawMessage {
+	var answer interface{}
+	switch ctl.Action {
+	case "list":
+		answer = h.localConnections()
+	case "disconnect":
+		answer = h.disconnectLocal(ctl.ClientID, ctl.UserID, ctl.Reason)
+	}
+	data, err := json.Marshal(answer)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
+
+// localConnections lists this node's clients
+func (h *Hub) localConnections() []models.ConnectionInfo {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	connections := make([]models.ConnectionInfo, 0, len(h.clients))
+	for client := range h.clients {
+		info := models.ConnectionInfo{
+			ID:            client.ID,
+			Node:          h.nodeID,
+			Role:          client.Role,
+			TeamID:        client.TeamID,
+			AuctionID:     client.AuctionID,
+			RemoteIP:      client.RemoteIP,
+			Transport:     "websocket",
+			ConnectedAt:   client.ConnectedAt,
+			QueueDepth:    len(client.send),
+			QueueCapacity: cap(client.send),
+		}
+		if client.UserID != uuid.Nil {
+			userID := client.UserID
+			info.UserID = &userID
+		}
+		if client.conn == nil {
+			info.Transport = "sse"
+		}
+		connections = append(connections, info)
+	}
+	return connections
+}
+
+// disconnectLocal drops this node's clients matching clientID or userID, recording the
+// reason for their close frame. Returns how many were dropped.
+func (h *Hub) disconnectLocal(clientID, userID *uuid.UUID, reason string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	closed := 0
+	for client := range h.clients {
+		if (clientID != nil && client.ID == *clientID) || (userID != nil && client.UserID == *userID) {
+			client.closeReason = reason
+			h.removeLocked(client)
+			closed++
+		}
+	}
+	if closed > 0 {
+		log.Printf("Disconnected %d client(s): %s", closed, reason)
+	}
+	return closed
+}
+
+// closeMessage returns the payload of the client's close frame: a policy close with the
+// reason when an admin disconnected it, else an empty frame
+func (c *Client) closeMessage() []byte {
+	if c.closeReason == "" {
+		return []byte{}
+	}
+	return websocket.FormatCloseMessage(websocket.ClosePolicyViolation, c.closeReason)
+}
+
+// closedEvent is the last event sent to an event stream an admin disconnected
+func closedEvent(reason string) []byte {
+	message, _ := json.Marshal(map[string]interface{}{
+		"event": "connection:closed",
+		"data":  map[string]string{"reason": reason},
+	})
+	return message
+}
+
+// truncateReason shortens a close reason to fit a close frame without splitting a character
+func truncateReason(reason string) string {
+	if reason == "" {
+		reason = "Disconnected by an administrator"
+	}
+	if len(reason) <= maxCloseReason {
+		return reason
+	}
+	cut := 0
+	for i := range reason {
+		if i > maxCloseReason {
+			break
+		}
+		cut = i
+	}
+	return reason[:cut]
+}
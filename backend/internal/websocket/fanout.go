@@ -0,0 +1,120 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// fanoutChannel is the Redis pub/sub channel every node publishes broadcasts to
+const fanoutChannel = "auction:ws:broadcast"
+
+// fanoutPublishTimeout bounds how long a broadcast waits on Redis
+const fanoutPublishTimeout = 2 * time.Second
+
+// fanoutMessage is a broadcast relayed between nodes
+type fanoutMessage struct {
+	Node    string          `json:"node"`
+	Seq     int64           `json:"seq"`               // 0 when the broadcast has none
+	Auction uuid.UUID       `json:"auction,omitempty"` // uuid.Nil means every auction
+	Role    string          `json:"role,omitempty"`    // empty means every role
+	Updates string          `json:"updates,omitempty"` // empty means both update modes
+	Message json.RawMessage `json:"message"`
+	Control *fanoutControl  `json:"control,omitempty"` // admin action instead of a broadcast
+}
+
+// EnableFanout relays broadcasts through Redis so clients connected to any node receive them.
+// Call before Run/RunFanout; without it the hub only reaches its own clients.
+func (h *Hub) EnableFanout(rdb *redis.Client) {
+	h.redis = rdb
+	h.nodeID = uuid.NewString()
+}
+
+// publish numbers a message, delivers it to this node's clients, then relays it to the
+// other nodes. A Redis outage only affects other nodes, never local delivery.
+func (h *Hub) publish(auction uuid.UUID, role, updates string, message []byte) {
+	seq, ok := h.nextSeq()
+	if ok {
+		message = withSeq(seq, message)
+	}
+	h.deliver(seq, auction, role, updates, message)
+
+	if h.redis == nil {
+		return
+	}
+	payload, err := json.Marshal(fanoutMessage{Node: h.nodeID, Seq: seq, Auction: auction, Role: role, Updates: updates, Message: message})
+	if err != nil {
+		log.Printf("Error marshaling fan-out message: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+	defer cancel()
+	if err := h.redis.Publish(ctx, fanoutChannel, payload).Err(); err != nil {
+		log.Printf("WARNING: broadcast fan-out publish failed: %v", err)
+	}
+}
+
+// deliver records a message for replay and sends it to this node's clients. A message
+// without a seq (0) cannot be replayed, so resumes from before it get a snapshot.
+func (h *Hub) deliver(seq int64, auction uuid.UUID, role, updates string, message []byte) {
+	if seq == 0 {
+		h.replay.skip()
+	} else {
+		h.replay.add(seq, auction, role, updates, message)
+	}
+	if auction == uuid.Nil && role == "" && updates == "" {
+		h.Broadcast <- message
+		return
+	}
+	h.deliverTo(message, auction, role, updates)
+}
+
+// RunFanout receives broadcasts published by other nodes and delivers them to this node's
+// clients until ctx is cancelled. The subscription reconnects on its own if Redis drops.
+func (h *Hub) RunFanout(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+
+	pubsub := h.redis.Subscribe(ctx, fanoutChannel)
+	defer pubsub.Close()
+
+	// Keep other nodes told whether this node still has snapshot clients, and who is connected
+	advertise := time.NewTicker(snapshotAdvertiseInterval)
+	defer advertise.Stop()
+	h.advertiseSnapshotClients()
+	h.advertisePresence(ctx)
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-advertise.C:
+			h.advertiseSnapshotClients()
+			h.advertisePresence(ctx)
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var relayed fanoutMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &relayed); err != nil {
+				log.Printf("Ignoring malformed fan-out message: %v", err)
+				continue
+			}
+			// This node already delivered its own broadcasts
+			if relayed.Node == h.nodeID {
+				continue
+			}
+			if relayed.Control != nil {
+				go h.handleControl(relayed.Control)
+				continue
+			}
+			h.deliver(relayed.Seq, relayed.Auction, relayed.Role, relayed.Updates, relayed.Message)
+		}
+	}
+}
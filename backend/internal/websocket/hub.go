@@ -4,10 +4,21 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 )
 
-// Hub maintains the set of active clients and broadcasts messages
+// Hub maintains the set of active clients and broadcasts messages. Every client follows
+// one auction; a Hub returned by ForAuction shares the clients of the hub it came from
+// but only broadcasts to, and registers clients for, that auction.
 type Hub struct {
+	*hubCore
+	auctionID uuid.UUID // uuid.Nil for the root hub, whose broadcasts reach every auction
+}
+
+// hubCore is the state shared by a hub and its auction views
+type hubCore struct {
 	// Registered clients
 	clients map[*Client]bool
 
@@ -15,7 +26,7 @@ type Hub struct {
 	Broadcast chan []byte
 
 	// Register requests from clients
-	register chan *Client
+	register chan registration
 
 	// Unregister requests from clients
 	unregister chan *Client
@@ -25,20 +36,48 @@ type Hub struct {
 
 	// Connection tracking for log deduplication
 	totalConnections int64
+
+	// Clients that still expect full auction:state broadcasts
+	snapshotClients int
+
+	// Who is connected to this node for each auction, and (single node only) when users
+	// were last seen
+	presence   map[uuid.UUID]nodePresence
+	lastSeen   map[uuid.UUID]map[uuid.UUID]presenceSeen
+	presenceMu sync.Mutex // serialises presence announcements
+
+	// Redis fan-out to other nodes (nil when running as a single node)
+	redis  *redis.Client
+	nodeID string
+
+	// Sequence numbers and recent broadcasts for resuming clients
+	replay   replayBuffer
+	seqMu    sync.Mutex
+	localSeq int64
 }
 
-// MaxConnections is the maximum number of concurrent WebSocket connections
+// MaxConnections is the maximum number of concurrent WebSocket connections per node
 // For 4GB RAM VPS, this provides headroom while preventing OOM
 const MaxConnections = 2000
 
 // NewHub creates a new Hub
 func NewHub() *Hub {
-	return &Hub{
+	return &Hub{hubCore: &hubCore{
 		Broadcast:  make(chan []byte, 256),
-		register:   make(chan *Client),
+		register:   make(chan registration),
 		unregister: make(chan *Client),
 		clients:    make(map[*Client]bool),
-	}
+	}}
+}
+
+// ForAuction returns a view of the hub for one auction's clients
+func (h *Hub) ForAuction(id uuid.UUID) *Hub {
+	return &Hub{hubCore: h.hubCore, auctionID: id}
+}
+
+// AuctionID returns the auction the hub broadcasts to, or uuid.Nil for every auction
+func (h *Hub) AuctionID() uuid.UUID {
+	return h.auctionID
 }
 
 // Run starts the Hub event loop
@@ -55,8 +94,12 @@ func (h *Hub) Run() {
 
 	for {
 		select {
-		case client := <-h.register:
+		case reg := <-h.register:
 			func() {
+				client := reg.client
+				var result registrationResult
+				// Always answer the connection handler, even after a panic
+				defer func() { reg.done <- result }()
 				// Panic recovery for individual operations
 				defer func() {
 					if r := recover(); r != nil {
@@ -71,10 +114,32 @@ func (h *Hub) Run() {
 					log.Printf("Connection rejected: max connections (%d) reached", MaxConnections)
 					return
 				}
+				// Queue missed events before the client can receive live ones.
+				// Holding the lock keeps role broadcasts from interleaving; events still
+				// queued for Run may arrive again afterwards and clients drop them by seq.
+				if reg.since >= 0 {
+					if missed, ok := h.replay.since(reg.since, client); ok {
+						for _, message := range missed {
+							client.send <- message
+						}
+						result.resumed = true
+					}
+				}
 				h.clients[client] = true
 				h.totalConnections++
 				clientCount := len(h.clients)
+				firstSnapshotClient := false
+				if !client.Patches {
+					h.snapshotClients++
+					firstSnapshotClient = h.snapshotClients == 1
+				}
+				h.trackLocked(client, 1)
 				h.mu.Unlock()
+				result.accepted = true
+				if firstSnapshotClient {
+					// Other nodes must resume full state broadcasts before the next change
+					go h.advertiseSnapshotClients()
+				}
 				
 				// Log every 100th connection or when fewer than 20 clients (development)
 				if h.totalConnections%100 == 1 || clientCount < 20 {
@@ -91,10 +156,7 @@ func (h *Hub) Run() {
 					}
 				}()
 				h.mu.Lock()
-				if _, ok := h.clients[client]; ok {
-					delete(h.clients, client)
-					close(client.send)
-				}
+				h.removeLocked(client)
 				clientCount := len(h.clients)
 				h.mu.Unlock()
 				
@@ -126,10 +188,7 @@ func (h *Hub) Run() {
 				if len(toRemove) > 0 {
 					h.mu.Lock()
 					for _, client := range toRemove {
-						if _, ok := h.clients[client]; ok {
-							close(client.send)
-							delete(h.clients, client)
-						}
+						h.removeLocked(client)
 					}
 					h.mu.Unlock()
 				}
@@ -149,7 +208,12 @@ func (h *Hub) BroadcastJSON(event string, data interface{}) {
 		log.Printf("Error marshaling broadcast message: %v", err)
 		return
 	}
-	h.Broadcast <- jsonData
+	h.publish(h.auctionID, "", "", jsonData)
+}
+
+// BroadcastRaw broadcasts an already-encoded message to all clients
+func (h *Hub) BroadcastRaw(message []byte) {
+	h.publish(h.auctionID, "", "", message)
 }
 
 // BroadcastToRole broadcasts a message only to clients with a specific role
@@ -163,13 +227,17 @@ func (h *Hub) BroadcastToRole(event string, data interface{}, role string) {
 		log.Printf("Error marshaling broadcast message: %v", err)
 		return
 	}
+	h.publish(h.auctionID, role, "", jsonData)
+}
 
+// deliverTo sends a message to this node's clients matching an auction, role and update mode
+func (h *Hub) deliverTo(message []byte, auction uuid.UUID, role, updates string) {
 	var toRemove []*Client
 	h.mu.RLock()
 	for client := range h.clients {
-		if client.Role == role {
+		if client.wants(auction, role, updates) {
 			select {
-			case client.send <- jsonData:
+			case client.send <- message:
 			default:
 				toRemove = append(toRemove, client)
 			}
@@ -180,15 +248,25 @@ func (h *Hub) BroadcastToRole(event string, data interface{}, role string) {
 	if len(toRemove) > 0 {
 		h.mu.Lock()
 		for _, client := range toRemove {
-			if _, ok := h.clients[client]; ok {
-				close(client.send)
-				delete(h.clients, client)
-			}
+			h.removeLocked(client)
 		}
 		h.mu.Unlock()
 	}
 }
 
+// removeLocked drops a client and closes its send channel. Callers hold h.mu.
+func (h *Hub) removeLocked(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	close(client.send)
+	if !client.Patches {
+		h.snapshotClients--
+	}
+	h.trackLocked(client, -1)
+}
+
 // ClientCount returns the number of connected clients
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// overlayRole is the hub role of broadcast graphics streams. They receive only
+// overlay:* events, never the regular auction events.
+const overlayRole = "overlay"
+
+// HandleOverlaySSE streams graphics-ready overlay events to an OBS/vMix browser source.
+// The stream opens with overlay:frame (every graphic at once), then sends overlay:lot,
+// overlay:bid, overlay:sold, overlay:purses and overlay:next-up as they change.
+// The caller checks the overlay token and passes the hub and services of the overlay's auction.
+func HandleOverlaySSE(c *fiber.Ctx, hub *Hub, svc *services.Services) error {
+	client := &Client{
+		send:   make(chan []byte, 256),
+		hub:    hub,
+		UserID: uuid.Nil,
+		Role:   overlayRole,
+		TeamID: nil,
+
+		RemoteIP: c.IP(),
+	}
+	return streamSSE(c, client, func() { client.sendOverlayFrame(hub, svc) })
+}
+
+// sendOverlayFrame sends every graphic, stamped with the latest sequence number
+func (c *Client) sendOverlayFrame(hub *Hub, svc *services.Services) {
+	seq := hub.LastSeq()
+	frame, err := svc.Overlay.Frame(context.Background())
+	if err != nil {
+		return
+	}
+	message, err := json.Marshal(map[string]interface{}{
+		"event": "overlay:frame",
+		"seq":   seq,
+		"data":  frame,
+	})
+	if err != nil {
+		return
+	}
+	c.trySend(message)
+}
+
+// BroadcastOverlay sends a graphics event to overlay streams
+func (h *Hub) BroadcastOverlay(event string, data interface{}) {
+	h.BroadcastToRole(event, data, overlayRole)
+}
+
+// BroadcastSold announces a sale to every client and puts the "SOLD" card on the overlays
+func (h *Hub) BroadcastSold(svc *services.Services, player *models.Player, team *models.Team) {
+	if player == nil || team == nil {
+		return
+	}
+	h.BroadcastJSON("auction:sold", fiber.Map{
+		"player": player,
+		"team":   team,
+		"amount": player.SoldPrice,
+	})
+	h.BroadcastOverlay("overlay:sold", svc.Overlay.SoldCard(player, team))
+}
+
+// broadcastOverlayChanges redraws the graphics a mutation touched
+func (h *Hub) broadcastOverlayChanges(ctx context.Context, svc *services.Services, changes StateChange) {
+	if changes&(ChangeLotOpened|ChangeLotClosed|ChangeLotUpdated|ChangeTeams|ChangeQueue) == 0 {
+		return
+	}
+	frame, err := svc.Overlay.Frame(ctx)
+	if err != nil {
+		return
+	}
+	if changes&(ChangeLotOpened|ChangeLotClosed|ChangeLotUpdated) != 0 {
+		h.BroadcastOverlay("overlay:lot", fiber.Map{"lot": frame.Lot, "bids": frame.Bids})
+	}
+	if changes&ChangeTeams != 0 {
+		h.BroadcastOverlay("overlay:purses", frame.Purses)
+	}
+	if changes&ChangeQueue != 0 {
+		h.BroadcastOverlay("overlay:next-up", frame.NextUp)
+	}
+}
@@ -0,0 +1,199 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// How a client keeps its auction state current. Clients connecting with ?patches=1 apply
+// patch:* events to their snapshot; everyone else still gets auction:state on every change.
+const (
+	updatesPatches   = "patches"
+	updatesSnapshots = "snapshots"
+)
+
+// snapshotNodesKey is a Redis sorted set of nodes with snapshot clients, scored by the
+// unix ms the entry expires at, so a crashed node stops counting on its own
+const snapshotNodesKey = "auction:ws:snapshot_nodes"
+
+// Nodes refresh their snapshotNodesKey entry every interval; it lapses after the TTL
+const (
+	snapshotAdvertiseInterval = 10 * time.Second
+	snapshotNodesTTL          = 30 * time.Second
+)
+
+// StateChange is a set of the parts of the auction state a mutation touched
+type StateChange uint8
+
+const (
+	ChangeStatus     StateChange = 1 << iota // auction status, host switches, timer
+	ChangeLotOpened                          // a new player is under the hammer
+	ChangeLotClosed                          // the player on the block was sold, unsold or skipped
+	ChangeLotUpdated                         // bids on the current lot changed other than by a new bid
+	ChangeTeams                              // purses or squad counts changed
+	ChangeQueue                              // the next players up changed
+)
+
+// wants reports whether a broadcast for an auction (uuid.Nil means any), role and update
+// mode (empty means any) is meant for the client
+func (c *Client) wants(auction uuid.UUID, role, updates string) bool {
+	if auction != uuid.Nil && c.AuctionID != auction {
+		return false
+	}
+	if role != "" && c.Role != role {
+		return false
+	}
+	// Overlays only take their own graphics events
+	if c.Role == overlayRole && role != overlayRole {
+		return false
+	}
+	switch updates {
+	case updatesPatches:
+		return c.Patches
+	case updatesSnapshots:
+		return !c.Patches
+	}
+	return true
+}
+
+// BroadcastPatch sends a patch event to clients that apply patches
+func (h *Hub) BroadcastPatch(event string, data interface{}) {
+	h.publishTo(updatesPatches, event, data)
+}
+
+// BroadcastChanges tells every client about a mutation: one patch per changed part for patch
+// clients, and the full state for snapshot clients when any are connected anywhere.
+// A patch costs one or two queries; the full state costs about nine when it has to be rebuilt.
+func (h *Hub) BroadcastChanges(ctx context.Context, svc *services.Services, changes StateChange) {
+	auction := svc.Auction
+	if changes&ChangeStatus != 0 {
+		if patch, err := auction.StatusPatch(ctx); err == nil {
+			h.BroadcastPatch("patch:status", patch)
+		}
+	}
+	if changes&(ChangeLotOpened|ChangeLotClosed|ChangeLotUpdated) != 0 {
+		event := "patch:lot-updated"
+		if changes&ChangeLotOpened != 0 {
+			event = "patch:lot-opened"
+		} else if changes&ChangeLotClosed != 0 {
+			event = "patch:lot-closed"
+		}
+		if patch, err := auction.LotPatch(ctx); err == nil {
+			h.BroadcastPatch(event, patch)
+		}
+	}
+	if changes&ChangeTeams != 0 {
+		if patch, err := auction.TeamsPatch(ctx); err == nil {
+			h.BroadcastPatch("patch:team-purses", patch)
+		}
+	}
+	if changes&ChangeQueue != 0 {
+		if patch, err := auction.QueuePatch(ctx); err == nil {
+			h.BroadcastPatch("patch:queue", patch)
+		}
+	}
+
+	h.broadcastOverlayChanges(ctx, svc, changes)
+
+	if !h.hasSnapshotClients(ctx) {
+		return
+	}
+	if state, err := auction.CachedState(ctx); err == nil {
+		h.publishTo(updatesSnapshots, "auction:state", state)
+	}
+}
+
+// BroadcastBid announces an accepted bid. Snapshot clients have always applied auction:bid
+// on its own; patch clients also get patch:bid-added with the lot fields the bid changed.
+func (h *Hub) BroadcastBid(ctx context.Context, svc *services.Services, bid *models.Bid) {
+	h.BroadcastJSON("auction:bid", bid)
+	if patch, err := svc.Auction.BidPatch(ctx, bid); err == nil {
+		h.BroadcastPatch("patch:bid-added", patch)
+	}
+	h.BroadcastOverlay("overlay:bid", svc.Overlay.BidItem(bid))
+	// The lot card shows the new bid, leader and countdown
+	h.broadcastOverlayChanges(ctx, svc, ChangeLotUpdated)
+}
+
+// BroadcastRTMOffer announces the right-to-match window the hammer opened. The lot stays
+// on the block, with its countdown now running for the former team.
+func (h *Hub) BroadcastRTMOffer(ctx context.Context, svc *services.Services, offer *models.RTMOffer) {
+	h.BroadcastJSON("auction:rtm", map[string]interface{}{
+		"stage": services.RTMOffered,
+		"offer": offer,
+	})
+	h.BroadcastChanges(ctx, svc, ChangeLotUpdated)
+}
+
+// BroadcastRTMResult announces how a right-to-match window closed and the sale it made
+func (h *Hub) BroadcastRTMResult(ctx context.Context, svc *services.Services, result *services.RTMResult) {
+	h.BroadcastJSON("auction:rtm", result)
+	h.BroadcastSold(svc, result.Player, result.Team)
+	h.BroadcastChanges(ctx, svc, ChangeLotClosed|ChangeTeams)
+}
+
+// publishTo broadcasts an event to the clients using one update mode
+func (h *Hub) publishTo(updates, event string, data interface{}) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"event": event,
+		"data":  data,
+	})
+	if err != nil {
+		log.Printf("Error marshaling broadcast message: %v", err)
+		return
+	}
+	h.publish(h.auctionID, "", updates, jsonData)
+}
+
+// hasSnapshotClients reports whether any node has a client that needs full state broadcasts.
+// When Redis cannot be asked it assumes so, since skipping the state would leave them stale.
+func (h *Hub) hasSnapshotClients(ctx context.Context) bool {
+	h.mu.RLock()
+	local := h.snapshotClients > 0
+	h.mu.RUnlock()
+	if local {
+		return true
+	}
+	if h.redis == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, fanoutPublishTimeout)
+	defer cancel()
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	nodes, err := h.redis.ZCount(ctx, snapshotNodesKey, now, "+inf").Result()
+	return err != nil || nodes > 0
+}
+
+// advertiseSnapshotClients records in Redis whether this node has snapshot clients
+func (h *Hub) advertiseSnapshotClients() {
+	if h.redis == nil {
+		return
+	}
+	h.mu.RLock()
+	local := h.snapshotClients > 0
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+	defer cancel()
+	var err error
+	if local {
+		expires := float64(time.Now().Add(snapshotNodesTTL).UnixMilli())
+		err = h.redis.ZAdd(ctx, snapshotNodesKey, redis.Z{Score: expires, Member: h.nodeID}).Err()
+	} else {
+		err = h.redis.ZRem(ctx, snapshotNodesKey, h.nodeID).Err()
+	}
+	if err != nil {
+		log.Printf("WARNING: could not advertise snapshot clients: %v", err)
+	}
+	// Drop entries left behind by nodes that went away
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	h.redis.ZRemRangeByScore(ctx, snapshotNodesKey, "-inf", "("+now)
+}
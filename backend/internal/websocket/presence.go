@@ -0,0 +1,368 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/auctionapp/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Presence is shared between nodes through Redis:
+//   - auction:ws:presence_nodes  sorted set of nodes, scored by the unix ms their entry expires
+//   - auction:ws:presence:<node> the node's connected users and anonymous connection counts, by auction
+//   - auction:ws:last_seen:<id>  hash of user ID to when they last connected to or
+//     disconnected from the auction
+const (
+	presenceNodesKey    = "auction:ws:presence_nodes"
+	presenceNodePrefix  = "auction:ws:presence:"
+	presenceLastSeenKey = "auction:ws:last_seen:"
+)
+
+// presenceWatchers are the roles told when a signed-in user joins or leaves
+var presenceWatchers = []string{"host", "admin", "org_admin", "super_admin"}
+
+// presenceConn is one signed-in user's connections to a node
+type presenceConn struct {
+	Role        string     `json:"role"`
+	TeamID      *uuid.UUID `json:"team_id,omitempty"`
+	Connections int        `json:"connections"`
+}
+
+// nodePresence is who is connected to one node for one auction
+type nodePresence struct {
+	Users     map[uuid.UUID]presenceConn `json:"users"`
+	Anonymous map[string]int             `json:"anonymous"` // role -> connections (viewers, overlays)
+}
+
+// presenceSeen is a user's last connect or disconnect
+type presenceSeen struct {
+	Role   string     `json:"role"`
+	TeamID *uuid.UUID `json:"team_id,omitempty"`
+	At     int64      `json:"at"` // unix ms
+}
+
+// trackLocked counts a client joining (delta 1) or leaving (delta -1) this node and, for
+// signed-in users, tells hosts about it. Callers hold h.mu.
+func (h *Hub) trackLocked(client *Client, delta int) {
+	if h.presence == nil {
+		h.presence = map[uuid.UUID]nodePresence{}
+	}
+	presence, ok := h.presence[client.AuctionID]
+	if !ok {
+		presence = nodePresence{Users: map[uuid.UUID]presenceConn{}, Anonymous: map[string]int{}}
+		h.presence[client.AuctionID] = presence
+	}
+	defer func() {
+		if len(presence.Users) == 0 && len(presence.Anonymous) == 0 {
+			delete(h.presence, client.AuctionID)
+		}
+	}()
+
+	if client.UserID == uuid.Nil {
+		presence.Anonymous[client.Role] += delta
+		if presence.Anonymous[client.Role] <= 0 {
+			delete(presence.Anonymous, client.Role)
+		}
+		return
+	}
+
+	conn := presence.Users[client.UserID]
+	conn.Role, conn.TeamID = client.Role, client.TeamID
+	conn.Connections += delta
+	if conn.Connections > 0 {
+		presence.Users[client.UserID] = conn
+	} else {
+		delete(presence.Users, client.UserID)
+	}
+
+	event := "presence:join"
+	if delta < 0 {
+		event = "presence:leave"
+	}
+	go h.announcePresence(event, client)
+}
+
+// announcePresence records a signed-in user's connect or disconnect and sends the hosts
+// of the client's auction the user's presence across every node. Announcements run one at a time, each reading after
+// its own write, so the last one sent always reflects the latest connections.
+func (h *Hub) announcePresence(event string, client *Client) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Error announcing presence: %v", r)
+		}
+	}()
+	h.presenceMu.Lock()
+	defer h.presenceMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+	defer cancel()
+
+	h = h.ForAuction(client.AuctionID)
+	h.markSeen(ctx, client)
+	h.advertisePresence(ctx)
+
+	nodes, seen := h.clusterPresence(ctx)
+	var teamIDs []uuid.UUID
+	if client.TeamID != nil {
+		teamIDs = []uuid.UUID{*client.TeamID}
+	}
+	presence := buildPresence(nodes, seen, teamIDs, time.Now())
+
+	payload := models.PresenceEvent{}
+	for _, user := range presence.Users {
+		if user.UserID == client.UserID {
+			payload.User = user
+			break
+		}
+	}
+	if len(presence.Teams) > 0 {
+		payload.Team = &presence.Teams[0]
+	}
+	for _, role := range presenceWatchers {
+		h.BroadcastToRole(event, payload, role)
+	}
+}
+
+// markSeen records now as the client's user's last-seen time in the hub's auction
+func (h *Hub) markSeen(ctx context.Context, client *Client) {
+	seen := presenceSeen{Role: client.Role, TeamID: client.TeamID, At: time.Now().UnixMilli()}
+	if h.redis == nil {
+		h.mu.Lock()
+		if h.lastSeen == nil {
+			h.lastSeen = map[uuid.UUID]map[uuid.UUID]presenceSeen{}
+		}
+		if h.lastSeen[h.auctionID] == nil {
+			h.lastSeen[h.auctionID] = map[uuid.UUID]presenceSeen{}
+		}
+		h.lastSeen[h.auctionID][client.UserID] = seen
+		h.mu.Unlock()
+		return
+	}
+	data, err := json.Marshal(seen)
+	if err != nil {
+		return
+	}
+	if err := h.redis.HSet(ctx, presenceLastSeenKey+h.auctionID.String(), client.UserID.String(), data).Err(); err != nil {
+		log.Printf("WARNING: could not record last seen: %v", err)
+	}
+}
+
+// localPresence returns a copy of this node's presence for every auction
+func (h *Hub) localPresence() map[uuid.UUID]nodePresence {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	local := make(map[uuid.UUID]nodePresence, len(h.presence))
+	for auction, presence := range h.presence {
+		copied := nodePresence{
+			Users:     make(map[uuid.UUID]presenceConn, len(presence.Users)),
+			Anonymous: make(map[string]int, len(presence.Anonymous)),
+		}
+		for id, conn := range presence.Users {
+			copied.Users[id] = conn
+		}
+		for role, n := range presence.Anonymous {
+			copied.Anonymous[role] = n
+		}
+		local[auction] = copied
+	}
+	return local
+}
+
+// advertisePresence publishes this node's presence for the other nodes. The entry lapses
+// with the node's snapshot advertisement TTL, so a crashed node's users go offline.
+func (h *Hub) advertisePresence(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+	data, err := json.Marshal(h.localPresence())
+	if err != nil {
+		return
+	}
+	expires := float64(time.Now().Add(snapshotNodesTTL).UnixMilli())
+	pipe := h.redis.TxPipeline()
+	pipe.Set(ctx, presenceNodePrefix+h.nodeID, data, snapshotNodesTTL)
+	pipe.ZAdd(ctx, presenceNodesKey, redis.Z{Score: expires, Member: h.nodeID})
+	pipe.ZRemRangeByScore(ctx, presenceNodesKey, "-inf", "("+strconv.FormatInt(time.Now().UnixMilli(), 10))
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("WARNING: could not advertise presence: %v", err)
+	}
+}
+
+// clusterPresence returns every live node's presence and the last-seen times in the hub's
+// auction. When Redis cannot be read it falls back to this node alone.
+func (h *Hub) clusterPresence(ctx context.Context) ([]nodePresence, map[uuid.UUID]presenceSeen) {
+	nodes := []nodePresence{h.localPresence()[h.auctionID]}
+	seen := map[uuid.UUID]presenceSeen{}
+
+	if h.redis == nil {
+		h.mu.RLock()
+		for id, s := range h.lastSeen[h.auctionID] {
+			seen[id] = s
+		}
+		h.mu.RUnlock()
+		return nodes, seen
+	}
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	ids, err := h.redis.ZRangeByScore(ctx, presenceNodesKey, &redis.ZRangeBy{Min: now, Max: "+inf"}).Result()
+	if err != nil {
+		log.Printf("WARNING: could not read presence: %v", err)
+		return nodes, seen
+	}
+	var keys []string
+	for _, id := range ids {
+		// This node's own entry may lag behind its memory
+		if id != h.nodeID {
+			keys = append(keys, presenceNodePrefix+id)
+		}
+	}
+	if len(keys) > 0 {
+		values, err := h.redis.MGet(ctx, keys...).Result()
+		if err == nil {
+			for _, v := range values {
+				raw, ok := v.(string)
+				if !ok {
+					continue
+				}
+				var node map[uuid.UUID]nodePresence
+				if json.Unmarshal([]byte(raw), &node) == nil {
+					nodes = append(nodes, node[h.auctionID])
+				}
+			}
+		}
+	}
+
+	all, err := h.redis.HGetAll(ctx, presenceLastSeenKey+h.auctionID.String()).Result()
+	if err == nil {
+		for key, raw := range all {
+			id, err := uuid.Parse(key)
+			if err != nil {
+				continue
+			}
+			var s presenceSeen
+			if json.Unmarshal([]byte(raw), &s) == nil {
+				seen[id] = s
+			}
+		}
+	}
+	return nodes, seen
+}
+
+// Presence returns who is connected to the hub's auction across every node, with a row for each of teamIDs
+// whether or not anyone from the team has ever connected
+func (h *Hub) Presence(ctx context.Context, teamIDs []uuid.UUID) *models.Presence {
+	nodes, seen := h.clusterPresence(ctx)
+	return buildPresence(nodes, seen, teamIDs, time.Now())
+}
+
+// buildPresence merges node presence and last-seen times into users, teams and roles.
+// Rows are sorted so the same connections always give the same JSON (and ETag).
+func buildPresence(nodes []nodePresence, seen map[uuid.UUID]presenceSeen, teamIDs []uuid.UUID, now time.Time) *models.Presence {
+	users := map[uuid.UUID]*models.UserPresence{}
+	for id, s := range seen {
+		at := time.UnixMilli(s.At)
+		users[id] = &models.UserPresence{UserID: id, Role: s.Role, TeamID: s.TeamID, LastSeen: &at}
+	}
+	anonymous := map[string]int{}
+	for _, node := range nodes {
+		for id, conn := range node.Users {
+			user := users[id]
+			if user == nil {
+				user = &models.UserPresence{UserID: id}
+				users[id] = user
+			}
+			user.Role, user.TeamID = conn.Role, conn.TeamID
+			user.Online = true
+			user.Connections += conn.Connections
+			user.LastSeen = &now
+		}
+		for role, n := range node.Anonymous {
+			anonymous[role] += n
+		}
+	}
+
+	presence := &models.Presence{
+		Teams: make([]models.TeamPresence, 0, len(teamIDs)),
+		Roles: []models.RolePresence{},
+		Users: make([]models.UserPresence, 0, len(users)),
+	}
+
+	teams := map[uuid.UUID]*models.TeamPresence{}
+	for _, id := range teamIDs {
+		teams[id] = &models.TeamPresence{TeamID: id}
+	}
+	roles := map[string]*models.RolePresence{}
+	for role, n := range anonymous {
+		roles[role] = &models.RolePresence{Role: role, Connections: n, LastSeen: &now}
+	}
+
+	for _, user := range users {
+		presence.Users = append(presence.Users, *user)
+
+		role := roles[user.Role]
+		if role == nil {
+			role = &models.RolePresence{Role: user.Role}
+			roles[user.Role] = role
+		}
+		mergePresence(&role.Connections, &role.LastSeen, user)
+		if user.Online {
+			role.Online++
+		}
+
+		if user.TeamID == nil {
+			continue
+		}
+		if team := teams[*user.TeamID]; team != nil {
+			mergePresence(&team.Connections, &team.LastSeen, user)
+			team.Online = team.Online || user.Online
+		}
+	}
+
+	for _, id := range teamIDs {
+		presence.Teams = append(presence.Teams, *teams[id])
+	}
+	for _, role := range roles {
+		presence.Roles = append(presence.Roles, *role)
+	}
+	sort.Slice(presence.Roles, func(i, j int) bool { return presence.Roles[i].Role < presence.Roles[j].Role })
+	sort.Slice(presence.Users, func(i, j int) bool {
+		a, b := presence.Users[i], presence.Users[j]
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.UserID.String() < b.UserID.String()
+	})
+	return presence
+}
+
+// mergePresence adds a user's connections to a group and keeps the group's latest last-seen
+func mergePresence(connections *int, lastSeen **time.Time, user *models.UserPresence) {
+	*connections += user.Connections
+	if user.LastSeen != nil && (*lastSeen == nil || user.LastSeen.After(**lastSeen)) {
+		*lastSeen = user.LastSeen
+	}
+}
+
+// HostView adds presence to the state when role is one that watches presence. state must
+// be a copy of its own (as CachedState returns), since the field is set in place.
+func (h *Hub) HostView(ctx context.Context, state *models.AuctionState, role string) *models.AuctionState {
+	watcher := false
+	for _, r := range presenceWatchers {
+		watcher = watcher || r == role
+	}
+	if !watcher {
+		return state
+	}
+	teamIDs := make([]uuid.UUID, 0, len(state.Teams))
+	for _, team := range state.Teams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+	state.Presence = h.Presence(ctx, teamIDs)
+	return state
+}
@@ -0,0 +1,184 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// seqKey is the Redis counter shared by all nodes so sequence numbers are global
+const seqKey = "auction:ws:seq"
+
+// replayBufferSize is how many recent broadcasts each node keeps for resuming clients
+const replayBufferSize = 1000
+
+// maxReplayEvents is the largest gap replayed event by event; larger gaps get a full snapshot.
+// Kept below the client send buffer so a replay never overflows it.
+const maxReplayEvents = 200
+
+// replayEntry is one broadcast kept for replay
+type replayEntry struct {
+	seq     int64
+	auction uuid.UUID // uuid.Nil means every auction
+	role    string    // empty means every role
+	updates string    // empty means both update modes
+	message []byte
+}
+
+// replayBuffer is a bounded, seq-ordered history of recent broadcasts
+type replayBuffer struct {
+	mu      sync.Mutex
+	entries []replayEntry
+	lastSeq int64
+	// gapBelow is one past lastSeq when a broadcast went out without a seq: resumes from
+	// below it may have missed that broadcast and need a snapshot
+	gapBelow int64
+}
+
+// add records a broadcast. Entries from other nodes can arrive slightly out of order,
+// so the entry is inserted in seq position.
+func (b *replayBuffer) add(seq int64, auction uuid.UUID, role, updates string, message []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := len(b.entries)
+	for i > 0 && b.entries[i-1].seq > seq {
+		i--
+	}
+	b.entries = append(b.entries, replayEntry{})
+	copy(b.entries[i+1:], b.entries[i:])
+	b.entries[i] = replayEntry{seq: seq, auction: auction, role: role, updates: updates, message: message}
+
+	if len(b.entries) > replayBufferSize {
+		b.entries = b.entries[len(b.entries)-replayBufferSize:]
+	}
+	if seq > b.lastSeq {
+		b.lastSeq = seq
+	}
+}
+
+// skip records a broadcast that has no seq and so cannot be replayed
+func (b *replayBuffer) skip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gapBelow = b.lastSeq + 1
+}
+
+// since returns the broadcasts meant for the client that it missed after seq.
+// ok is false when the gap cannot be replayed and the client needs a full snapshot.
+func (b *replayBuffer) since(seq int64, client *Client) (messages [][]byte, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if seq < b.gapBelow {
+		// A broadcast the client may have missed was never numbered
+		return nil, false
+	}
+	if seq > b.lastSeq {
+		// The client saw a sequence this node never did (e.g. the counter was reset)
+		return nil, false
+	}
+	if seq == b.lastSeq {
+		return nil, true
+	}
+	if len(b.entries) == 0 || b.entries[0].seq > seq+1 {
+		// Events the client missed have already been dropped from the buffer
+		return nil, false
+	}
+	for _, e := range b.entries {
+		if e.seq <= seq || !client.wants(e.auction, e.role, e.updates) {
+			continue
+		}
+		if len(messages) == maxReplayEvents {
+			return nil, false
+		}
+		messages = append(messages, e.message)
+	}
+	return messages, true
+}
+
+// last returns the highest sequence number seen
+func (b *replayBuffer) last() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastSeq
+}
+
+// nextSeq allocates the sequence number for a new broadcast. With Redis the counter is
+// shared by every node; without it the node counts on its own. ok is false when Redis
+// fails: a number made up locally could collide with another node's, so the broadcast
+// goes out without one and resumes from before it get a snapshot.
+//
+// Numbers are allocated before publishing, so broadcasts from different nodes can reach
+// a node out of seq order (11 before 10). Clients therefore resume from the lowest seq
+// they have not seen rather than the highest they have, and drop repeats by seq.
+func (h *Hub) nextSeq() (seq int64, ok bool) {
+	if h.redis != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), fanoutPublishTimeout)
+		defer cancel()
+		seq, err := h.redis.Incr(ctx, seqKey).Result()
+		if err != nil {
+			log.Printf("WARNING: broadcast seq allocation failed, sending unnumbered: %v", err)
+			return 0, false
+		}
+		return seq, true
+	}
+	h.seqMu.Lock()
+	defer h.seqMu.Unlock()
+	h.localSeq = max(h.localSeq, h.replay.last()) + 1
+	return h.localSeq, true
+}
+
+// LastSeq returns the sequence number of the latest broadcast this node has seen
+func (h *Hub) LastSeq() int64 {
+	return h.replay.last()
+}
+
+// withSeq adds a "seq" field to an encoded JSON object
+func withSeq(seq int64, message []byte) []byte {
+	message = bytes.TrimSpace(message)
+	if len(message) < 2 || message[0] != '{' {
+		return message
+	}
+	prefix := `{"seq":` + strconv.FormatInt(seq, 10)
+	body := bytes.TrimSpace(message[1:])
+	if len(body) > 0 && body[0] == '}' {
+		return append([]byte(prefix), body...)
+	}
+	out := make([]byte, 0, len(prefix)+1+len(body))
+	out = append(out, prefix...)
+	out = append(out, ',')
+	return append(out, body...)
+}
+
+// registration is a client joining the hub, optionally resuming after a sequence number
+type registration struct {
+	client *Client
+	since  int64 // -1 for a fresh connection
+	done   chan registrationResult
+}
+
+// registrationResult tells the connection handler what happened at registration
+type registrationResult struct {
+	accepted bool
+	resumed  bool // missed events were replayed; no snapshot needed
+}
+
+// Register adds a client to the hub, following the hub's auction. With since >= 0 the broadcasts the client missed
+// after that sequence are queued to it first, before any live event; resumed is false
+// when the gap is too large and the caller should send a full snapshot instead.
+// accepted is false when the node is at its connection limit.
+func (h *Hub) Register(client *Client, since int64) (accepted, resumed bool) {
+	client.ID = uuid.New()
+	client.ConnectedAt = time.Now()
+	client.AuctionID = h.auctionID
+	reg := registration{client: client, since: since, done: make(chan registrationResult, 1)}
+	h.register <- reg
+	result := <-reg.done
+	return result.accepted, result.resumed
+}
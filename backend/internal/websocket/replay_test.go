@@ -0,0 +1,168 @@
+package websocket
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// replayMessages fills a buffer with broadcasts seq from..to, each message being its seq
+func replayMessages(b *replayBuffer, from, to int64, auction uuid.UUID, role, updates string) {
+	for seq := from; seq <= to; seq++ {
+		b.add(seq, auction, role, updates, []byte(fmt.Sprint(seq)))
+	}
+}
+
+func TestReplayBufferSince(t *testing.T) {
+	auction := uuid.New()
+	other := uuid.New()
+
+	var b replayBuffer
+	replayMessages(&b, 1, 3, auction, "", "")
+	b.add(4, other, "", "", []byte("4"))               // another auction's
+	b.add(5, auction, "host", "", []byte("5"))         // hosts only
+	b.add(6, auction, "", updatesPatches, []byte("6")) // patch clients only
+	b.add(7, uuid.Nil, "", "", []byte("7"))            // every auction
+
+	bidder := &Client{AuctionID: auction, Role: "bidder"}
+	host := &Client{AuctionID: auction, Role: "host", Patches: true}
+
+	tests := []struct {
+		name   string
+		seq    int64
+		client *Client
+		want   []string
+		wantOK bool
+	}{
+		{"up to date", 7, bidder, nil, true},
+		{"missed the last", 6, bidder, []string{"7"}, true},
+		{"filtered for a bidder", 2, bidder, []string{"3", "7"}, true},
+		{"filtered for a patching host", 2, host, []string{"3", "5", "6", "7"}, true},
+		{"from the start", 0, bidder, []string{"1", "2", "3", "7"}, true},
+		{"ahead of this node", 8, bidder, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, ok := b.since(tt.seq, tt.client)
+			var got []string
+			for _, m := range messages {
+				got = append(got, string(m))
+			}
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("since(%d) = %v, %v, want %v, %v", tt.seq, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReplayBufferGaps(t *testing.T) {
+	auction := uuid.New()
+	client := &Client{AuctionID: auction}
+
+	// Entries relayed from other nodes arrive out of order
+	var b replayBuffer
+	for _, seq := range []int64{2, 1, 4, 3} {
+		b.add(seq, auction, "", "", []byte(fmt.Sprint(seq)))
+	}
+	if messages, ok := b.since(0, client); !ok || len(messages) != 4 || string(messages[0]) != "1" || string(messages[3]) != "4" {
+		t.Fatalf("since(0) = %q, %v, want 1 to 4 in order", messages, ok)
+	}
+	if b.last() != 4 {
+		t.Fatalf("last() = %d, want 4", b.last())
+	}
+
+	// The oldest entries are dropped once the buffer is full
+	var full replayBuffer
+	replayMessages(&full, 1, replayBufferSize+10, auction, "", "")
+	if _, ok := full.since(5, client); ok {
+		t.Error("since a dropped seq replayed, want a snapshot")
+	}
+	if messages, ok := full.since(replayBufferSize+10-maxReplayEvents, client); !ok || len(messages) != maxReplayEvents {
+		t.Errorf("since the largest replayable gap = %d messages, %v, want %d", len(messages), ok, maxReplayEvents)
+	}
+	if _, ok := full.since(replayBufferSize+9-maxReplayEvents, client); ok {
+		t.Error("a gap over maxReplayEvents replayed, want a snapshot")
+	}
+}
+
+func TestNextSeqWithoutRedis(t *testing.T) {
+	h := NewHub()
+	if seq, ok := h.nextSeq(); !ok || seq != 1 {
+		t.Fatalf("first seq = %d, %v, want 1, true", seq, ok)
+	}
+	// A relayed broadcast moves the local counter past it
+	h.replay.add(10, uuid.Nil, "", "", []byte("{}"))
+	if seq, ok := h.nextSeq(); !ok || seq != 11 {
+		t.Fatalf("seq after a relayed 10 = %d, %v, want 11, true", seq, ok)
+	}
+	if h.LastSeq() != 10 {
+		t.Fatalf("LastSeq() = %d, want 10", h.LastSeq())
+	}
+}
+
+func TestNextSeqRedisDown(t *testing.T) {
+	h := NewHub()
+	h.replay.add(10, uuid.Nil, "", "", []byte("{}"))
+	h.EnableFanout(redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1}))
+	t.Cleanup(func() { h.redis.Close() })
+
+	if seq, ok := h.nextSeq(); ok {
+		t.Fatalf("nextSeq with Redis down = %d, want no seq", seq)
+	}
+}
+
+func TestReplayBufferSkip(t *testing.T) {
+	client := &Client{AuctionID: uuid.New(), Role: "bidder"}
+	var b replayBuffer
+	replayMessages(&b, 1, 5, uuid.Nil, "", "")
+	b.skip()
+	replayMessages(&b, 6, 7, uuid.Nil, "", "")
+
+	// Resuming from before the unnumbered broadcast needs a snapshot
+	for _, seq := range []int64{3, 5} {
+		if _, ok := b.since(seq, client); ok {
+			t.Errorf("since(%d) across an unnumbered broadcast replayed, want a snapshot", seq)
+		}
+	}
+	if messages, ok := b.since(6, client); !ok || len(messages) != 1 || string(messages[0]) != "7" {
+		t.Errorf("since(6) = %q, %v, want [7], true", messages, ok)
+	}
+}
+
+func TestWithSeq(t *testing.T) {
+	tests := []struct {
+		message, want string
+	}{
+		{`{"event":"bid"}`, `{"seq":7,"event":"bid"}`},
+		{` {} `, `{"seq":7}`},
+		{`{ }`, `{"seq":7}`},
+		{`[1,2]`, `[1,2]`},
+		{``, ``},
+	}
+	for _, tt := range tests {
+		if got := string(withSeq(7, []byte(tt.message))); got != tt.want {
+			t.Errorf("withSeq(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		param string
+		want  int64
+	}{
+		{"", -1},
+		{"0", 0},
+		{"42", 42},
+		{"-3", -1},
+		{"abc", -1},
+	}
+	for _, tt := range tests {
+		if got := parseSince(tt.param); got != tt.want {
+			t.Errorf("parseSince(%q) = %d, want %d", tt.param, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/auctionapp/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// sseRetry tells EventSource how long to wait before reconnecting
+const sseRetry = 2 * time.Second
+
+// HandlePublicSSE streams the public hub events as Server-Sent Events, for viewers behind
+// proxies that block WebSockets and for OBS browser sources and simple embeds.
+// Every frame is the same JSON a /ws-public client receives, sent with its seq as the
+// event id, so a reconnecting EventSource resumes through Last-Event-ID (or ?since=).
+// Streams count toward the hub's connection limit like any other client, and follow the
+// auction named by ?auction=, else the default auction.
+func HandlePublicSSE(c *fiber.Ctx, hub *Hub, svc *services.Services) error {
+	auction, err := svc.Auctions.Resolve(c.Context(), c.Query("auction"), nil)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Auction not found",
+		})
+	}
+	hub, svc = hub.ForAuction(auction.ID), svc.ForAuction(auction.ID)
+
+	client := &Client{
+		send:    make(chan []byte, 256),
+		hub:     hub,
+		UserID:  uuid.Nil,
+		Role:    "viewer",
+		TeamID:  nil,
+		Patches: c.Query("patches") == "1",
+
+		RemoteIP: c.IP(),
+	}
+	return streamSSE(c, client, func() { client.sendSnapshot(hub, svc) })
+}
+
+// streamSSE registers the client with the hub and streams its messages as events.
+// snapshot is called when the client is not resuming, to send its starting state.
+func streamSSE(c *fiber.Ctx, client *Client, snapshot func()) error {
+	since := c.Get("Last-Event-ID")
+	if since == "" {
+		since = c.Query("since")
+	}
+
+	accepted, resumed := client.hub.Register(client, parseSince(since))
+	if !accepted {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Server is at capacity",
+		})
+	}
+	if !resumed {
+		snapshot()
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // stop nginx holding events back
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		client.ssePump(w)
+	})
+	return nil
+}
+
+// ssePump writes queued hub messages to the event stream until the viewer goes away.
+// A disconnect only shows up as a failed write, so a comment is sent every pingPeriod.
+func (c *Client) ssePump(w *bufio.Writer) {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.hub.unregister <- c
+	}()
+
+	w.WriteString("retry: " + strconv.FormatInt(sseRetry.Milliseconds(), 10) + "\n\n")
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			if !ok {
+				// Dropped by the hub (too slow, shutting down or disconnected by an admin)
+				if c.closeReason != "" {
+					writeSSE(w, closedEvent(c.closeReason))
+					w.Flush()
+				}
+				return
+			}
+			writeSSE(w, message)
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			w.WriteString(": ping\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeSSE writes one hub message as an event, using its seq (if any) as the event id.
+// Hub messages are compact JSON, so they always fit on a single data line.
+func writeSSE(w *bufio.Writer, message []byte) {
+	var head struct {
+		Seq *int64 `json:"seq"`
+	}
+	if json.Unmarshal(message, &head) == nil && head.Seq != nil {
+		w.WriteString("id: " + strconv.FormatInt(*head.Seq, 10) + "\n")
+	}
+	w.WriteString("data: ")
+	w.Write(message)
+	w.WriteString("\n\n")
+}
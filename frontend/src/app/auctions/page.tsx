@@ -37,6 +37,7 @@ import { useWebSocket } from '@/hooks/useWebSocket';
 import api from '@/lib/api';
 import confetti from 'canvas-confetti';
 import { getImageUrl } from '@/lib/utils';
+import { bidLadderFrom } from '@/lib/bidLadder';
 
 // --- Types ---
 interface Team {
@@ -80,6 +81,8 @@ interface AuctionState {
     current_bid?: number;
     current_bidder?: Team;
     bids?: any[];
+    bid_ladder?: number[];
+    max_bid?: number;
 }
 
 interface Bid {
@@ -134,6 +137,9 @@ export default function AuctionsPage() {
     const [teamSquad, setTeamSquad] = useState<Player[]>([]);
     const [loading, setLoading] = useState(true);
 
+    // Top of the bid ladder - a sale there between several teams was decided by toss
+    const { maxBid } = bidLadderFrom(auctionState);
+
     // Track last sold/unsold player for display
     const [lastSoldPlayer, setLastSoldPlayer] = useState<Player | null>(null);
     const [lastUnsoldPlayer, setLastUnsoldPlayer] = useState<Player | null>(null);
@@ -275,6 +281,10 @@ export default function AuctionsPage() {
                     status: 'live',
                 }));
                 break;
+            case 'auction:bid-ladder':
+                // The bid_increments setting changed
+                setAuctionState(prev => prev ? { ...prev, bid_ladder: data.ladder, max_bid: data.max_bid } : null);
+                break;
             case 'auction:ended':
                 // Auction has ended - FORCE update state to show completed status
                 // This MUST clear everything and set status to completed, no matter what
@@ -1000,7 +1010,7 @@ export default function AuctionsPage() {
                                                         </div>
 
                                                         {/* Toss/Tie-Breaker Indicator - Show when sold at max bid with competing teams */}
-                                                        {lastSoldPlayer.sold_price === 50000 && soldPlayerBidHistory.filter(b => b.amount === 50000).length > 1 && (
+                                                        {lastSoldPlayer.sold_price === maxBid && soldPlayerBidHistory.filter(b => b.amount === maxBid).length > 1 && (
                                                             <div className="bg-gradient-to-r from-amber-500/20 to-orange-500/20 backdrop-blur-md rounded-xl px-4 py-3 border border-amber-400/30 shadow-xl text-center mb-3 sm:mb-6 w-full animate-fadeIn">
                                                                 <div className="flex items-center justify-center gap-2 mb-2">
                                                                     <div className="w-5 h-5 rounded-full bg-amber-400 flex items-center justify-center">
@@ -1014,7 +1024,7 @@ export default function AuctionsPage() {
                                                                     Tie-breaker between{' '}
                                                                     {(() => {
                                                                         const maxBidTeams = soldPlayerBidHistory
-                                                                            .filter(b => b.amount === 50000)
+                                                                            .filter(b => b.amount === maxBid)
                                                                             .reduce((acc: { id: string; name: string }[], bid) => {
                                                                                 if (bid.team && !acc.find(t => t.id === bid.team?.id)) {
                                                                                     acc.push({ id: bid.team.id, name: bid.team.name });
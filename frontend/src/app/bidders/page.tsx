@@ -40,6 +40,7 @@ import { useWebSocket } from '@/hooks/useWebSocket';
 import api from '@/lib/api';
 import { toast } from 'sonner';
 import { getImageUrl } from '@/lib/utils';
+import { bidLadderFrom, nextBidAmount, upcomingBidAmounts } from '@/lib/bidLadder';
 
 // --- Types ---
 interface Team {
@@ -97,6 +98,8 @@ interface AuctionState {
     bids?: Bid[];
     bid_frozen?: boolean;
     bidder_bidding_disabled?: boolean;
+    bid_ladder?: number[];
+    max_bid?: number;
 }
 
 // --- Helpers ---
@@ -276,6 +279,10 @@ export default function BidderDashboard() {
                     api.getTeamSquad(user.team_id).then(s => setMySquad(s || []));
                 }
                 break;
+            case 'auction:bid-ladder':
+                // The bid_increments setting changed
+                setAuctionState(prev => prev ? { ...prev, bid_ladder: data.ladder, max_bid: data.max_bid } : null);
+                break;
             case 'auction:ended':
                 // Auction has ended - update state to show completed status immediately
                 setAuctionState(prev => prev ? {
@@ -293,7 +300,6 @@ export default function BidderDashboard() {
 
     // WebSocket connection with reconnection state sync
     const { isConnected, reconnect } = useWebSocket({
-        token: api.getToken(),
         enabled: isAuthenticated,
         onMessage: handleWebSocketMessage,
         onOpen: useCallback(() => {
@@ -467,58 +473,23 @@ export default function BidderDashboard() {
         );
     }, [upcomingQueue, upcomingSearchQuery]);
 
-    // Bid ladder - same as backend
-    const BID_LADDER = [
-        2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000,
-        12000, 14000, 16000, 18000, 20000,
-        24000, 28000, 32000, 36000, 40000, 45000, 50000,
-    ];
-    const MAX_BID = 50000;
+    // Bid ladder from the auction state (the bid_increments setting)
+    const { ladder: bidLadder, maxBid } = bidLadderFrom(auctionState);
 
     // Get next valid bid amount from the ladder
     const getNextBidAmount = () => {
         const currentBid = auctionState?.current_bid || 0;
-        const basePrice = auctionState?.current_player?.base_price || 2000;
+        const basePrice = auctionState?.current_player?.base_price || bidLadder[0];
         const hasBidder = !!auctionState?.current_bidder;
-
-        // If no bids yet (no current bidder), first bid should be at base_price
-        if (!hasBidder) {
-            // Find base price in ladder or return base price
-            for (const amount of BID_LADDER) {
-                if (amount >= basePrice) return amount;
-            }
-            return basePrice;
-        }
-
-        // Find the next bid in the ladder (must be > current bid)
-        for (const amount of BID_LADDER) {
-            if (amount > currentBid) return amount;
-        }
-        // At max bid, can still bid 50000 (tie allowed)
-        return MAX_BID;
+        // At max bid the max can still be matched (tie allowed)
+        return nextBidAmount(bidLadder, maxBid, currentBid, basePrice, hasBidder);
     };
 
     // Get upcoming bid options (next 3 valid amounts)
     const getUpcomingBids = () => {
         const currentBid = auctionState?.current_bid || 0;
         const hasBidder = !!auctionState?.current_bidder;
-        const upcoming: number[] = [];
-
-        for (const amount of BID_LADDER) {
-            // If no bidder yet, include amounts >= current_bid (which is base_price)
-            // If there is a bidder, only include amounts > current_bid
-            if ((!hasBidder && amount >= currentBid) || (hasBidder && amount > currentBid)) {
-                upcoming.push(amount);
-                if (upcoming.length >= 3) break;
-            }
-        }
-
-        // If at or near max, show 50000 option
-        if (upcoming.length === 0 || (currentBid >= MAX_BID - 5000 && !upcoming.includes(MAX_BID))) {
-            upcoming.push(MAX_BID);
-        }
-
-        return upcoming.slice(0, 3);
+        return upcomingBidAmounts(bidLadder, maxBid, currentBid, hasBidder);
     };
 
     // Handle placing a bid
@@ -48,6 +48,7 @@ import { useWebSocket } from '@/hooks/useWebSocket';
 import api from '@/lib/api';
 import { toast } from 'sonner';
 import { getImageUrl } from '@/lib/utils';
+import { bidLadderFrom, nextBidAmount } from '@/lib/bidLadder';
 
 // --- Types ---
 interface Team {
@@ -102,6 +103,8 @@ interface AuctionState {
     bids?: Bid[];
     tied_teams?: Team[];
     bidder_bidding_disabled?: boolean;
+    bid_ladder?: number[];
+    max_bid?: number;
 }
 
 // --- Helpers ---
@@ -285,6 +288,10 @@ export default function HostDashboard() {
                 setTimer(0);
                 setAuctionState(prev => prev ? { ...prev, status: 'completed', current_player: undefined } : null);
                 break;
+            case 'auction:bid-ladder':
+                // The bid_increments setting changed
+                setAuctionState(prev => prev ? { ...prev, bid_ladder: data.ladder, max_bid: data.max_bid } : null);
+                break;
             case 'auction:bid-undone':
                 // Handle bid undo event from WebSocket
                 if (data?.bids) {
@@ -302,7 +309,6 @@ export default function HostDashboard() {
     }, []);
 
     const { isConnected } = useWebSocket({
-        token: api.getToken(),
         enabled: isAuthenticated,
         onMessage: handleWebSocketMessage,
         onOpen: useCallback(() => {
@@ -710,40 +716,15 @@ export default function HostDashboard() {
         }
     };
 
-    // Bid ladder - same as backend
-    const BID_LADDER = [
-        2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000,
-        12000, 14000, 16000, 18000, 20000,
-        24000, 28000, 32000, 36000, 40000, 45000, 50000,
-    ];
-    const MAX_BID = 50000;
+    // Bid ladder from the auction state (the bid_increments setting)
+    const { ladder: bidLadder, maxBid } = bidLadderFrom(auctionState);
 
     // Get next valid bid amount from the ladder
     const getNextBidAmount = () => {
         const currentBid = auctionState?.current_bid || 0;
-        const basePrice = auctionState?.current_player?.base_price || 2000;
+        const basePrice = auctionState?.current_player?.base_price || bidLadder[0];
         const hasBidder = !!auctionState?.current_bidder;
-
-        // If no bids yet (no current bidder), first bid should be at base_price
-        if (!hasBidder) {
-            // Find the first ladder amount >= base_price
-            for (const amount of BID_LADDER) {
-                if (amount >= basePrice) {
-                    return amount;
-                }
-            }
-            return basePrice;
-        }
-
-        // If there's a current bidder, find the next amount > current_bid
-        for (const amount of BID_LADDER) {
-            if (amount > currentBid) {
-                return amount;
-            }
-        }
-
-        // If at or above max, return max
-        return MAX_BID;
+        return nextBidAmount(bidLadder, maxBid, currentBid, basePrice, hasBidder);
     };
 
     // Handle placing a bid for a team (Master Bid Controls)
@@ -1102,7 +1083,7 @@ export default function HostDashboard() {
                                                                 <p className="text-sm font-semibold text-slate-800 mb-3">Select Bid Amount</p>
                                                                 <ScrollArea className="h-40 border border-slate-200 rounded-lg p-2">
                                                                     <div className="grid grid-cols-4 md:grid-cols-6 gap-2">
-                                                                        {BID_LADDER.map(amount => {
+                                                                        {bidLadder.map(amount => {
                                                                             const isSelected = selectedRandomBidAmount === amount;
                                                                             const canAfford = selectedRandomTeam ? (selectedRandomTeam.remaining_budget || (selectedRandomTeam.budget - (selectedRandomTeam.spent || 0))) >= amount : true;
                                                                             return (
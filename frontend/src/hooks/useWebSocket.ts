@@ -1,6 +1,7 @@
 'use client';
 
 import { useEffect, useRef, useCallback, useState } from 'react';
+import api from '@/lib/api';
 
 const WS_URL = process.env.NEXT_PUBLIC_WS_URL || 'ws://localhost:3001';
 
@@ -16,8 +17,6 @@ interface WebSocketMessage {
 }
 
 interface UseWebSocketOptions {
-    /** Auth token for authenticated connections */
-    token?: string | null;
     /** Use public endpoint (no auth required) */
     isPublic?: boolean;
     /** Called when a message is received */
@@ -51,7 +50,6 @@ interface UseWebSocketReturn {
  */
 export function useWebSocket(options: UseWebSocketOptions = {}): UseWebSocketReturn {
     const {
-        token,
         isPublic = false,
         onMessage,
         onOpen,
@@ -85,17 +83,20 @@ export function useWebSocket(options: UseWebSocketOptions = {}): UseWebSocketRet
     onCloseRef.current = onClose;
     onErrorRef.current = onError;
 
-    // Build WebSocket URL
-    const getWsUrl = useCallback(() => {
+    // Build WebSocket URL. Authenticated sockets open with a fresh single-use ticket on
+    // every (re)connect, so the access token never appears in the URL.
+    const getWsUrl = useCallback(async () => {
         if (isPublic) {
             return `${WS_URL}/ws-public`;
         }
-        if (token) {
-            return `${WS_URL}/ws?token=${token}`;
+        try {
+            const { ticket } = await api.getWSTicket();
+            return `${WS_URL}/ws?ticket=${encodeURIComponent(ticket)}`;
+        } catch {
+            // Fallback for cookie-based auth
+            return `${WS_URL}/ws`;
         }
-        // Fallback for cookie-based auth
-        return `${WS_URL}/ws`;
-    }, [isPublic, token]);
+    }, [isPublic]);
 
     // Clear all timers
     const clearTimers = useCallback(() => {
@@ -129,15 +130,13 @@ export function useWebSocket(options: UseWebSocketOptions = {}): UseWebSocketRet
 
     // Connect function with deduplication
     const connect = useCallback(() => {
-        const wsUrl = getWsUrl();
-
         // Guard: Check if already connecting or connected
         if (connectionStateRef.current === 'connecting' || connectionStateRef.current === 'connected') {
             return;
         }
 
-        // Guard: Check if enabled and URL is valid
-        if (!enabled || !wsUrl) {
+        // Guard: Check if enabled
+        if (!enabled) {
             return;
         }
 
@@ -159,81 +158,88 @@ export function useWebSocket(options: UseWebSocketOptions = {}): UseWebSocketRet
             wsRef.current = null;
         }
 
-        try {
-            const ws = new WebSocket(wsUrl);
-            wsRef.current = ws;
-
-            ws.onopen = () => {
-                // Verify this is still the current connection
-                if (connectionIdRef.current !== currentConnectionId || !mountedRef.current) {
-                    ws.close();
-                    return;
-                }
-
-                connectionStateRef.current = 'connected';
-                setIsConnected(true);
-                reconnectAttempts.current = 0; // Reset on successful connection
-                onOpenRef.current?.();
-
-                // Start ping interval to keep connection alive
-                pingIntervalRef.current = setInterval(() => {
-                    if (ws.readyState === WebSocket.OPEN) {
-                        ws.send(JSON.stringify({ event: 'ping' }));
-                    }
-                }, PING_INTERVAL);
-            };
-
-            ws.onmessage = (event) => {
-                // Verify this is still the current connection
-                if (connectionIdRef.current !== currentConnectionId) return;
-
-                try {
-                    const message: WebSocketMessage = JSON.parse(event.data);
-                    // Ignore pong messages (heartbeat response)
-                    if (message.event !== 'pong') {
-                        onMessageRef.current?.(message.event, message.data);
+        getWsUrl().then((wsUrl) => {
+            // Verify this is still the current connection after fetching the ticket
+            if (connectionIdRef.current !== currentConnectionId || !mountedRef.current) {
+                return;
+            }
+
+            try {
+                const ws = new WebSocket(wsUrl);
+                wsRef.current = ws;
+
+                ws.onopen = () => {
+                    // Verify this is still the current connection
+                    if (connectionIdRef.current !== currentConnectionId || !mountedRef.current) {
+                        ws.close();
+                        return;
                     }
-                } catch {
-                    // Silently ignore parse errors
-                }
-            };
 
-            ws.onclose = (event) => {
-                // Verify this is still the current connection
-                if (connectionIdRef.current !== currentConnectionId) return;
+                    connectionStateRef.current = 'connected';
+                    setIsConnected(true);
+                    reconnectAttempts.current = 0; // Reset on successful connection
+                    onOpenRef.current?.();
 
-                connectionStateRef.current = 'disconnected';
-                setIsConnected(false);
-                onCloseRef.current?.();
-
-                // Clear ping interval
-                if (pingIntervalRef.current) {
-                    clearInterval(pingIntervalRef.current);
-                    pingIntervalRef.current = null;
-                }
-
-                // Schedule reconnection with backoff (if still mounted and enabled)
-                if (enabled && mountedRef.current) {
-                    const delay = getReconnectDelay();
-                    reconnectAttempts.current++;
-
-                    reconnectTimeoutRef.current = setTimeout(() => {
-                        if (mountedRef.current) {
-                            connect();
+                    // Start ping interval to keep connection alive
+                    pingIntervalRef.current = setInterval(() => {
+                        if (ws.readyState === WebSocket.OPEN) {
+                            ws.send(JSON.stringify({ event: 'ping' }));
                         }
-                    }, delay);
-                }
-            };
+                    }, PING_INTERVAL);
+                };
+
+                ws.onmessage = (event) => {
+                    // Verify this is still the current connection
+                    if (connectionIdRef.current !== currentConnectionId) return;
+
+                    try {
+                        const message: WebSocketMessage = JSON.parse(event.data);
+                        // Ignore pong messages (heartbeat response)
+                        if (message.event !== 'pong') {
+                            onMessageRef.current?.(message.event, message.data);
+                        }
+                    } catch {
+                        // Silently ignore parse errors
+                    }
+                };
 
-            ws.onerror = (error) => {
-                // Verify this is still the current connection
-                if (connectionIdRef.current !== currentConnectionId) return;
-                onErrorRef.current?.(error);
-            };
+                ws.onclose = (event) => {
+                    // Verify this is still the current connection
+                    if (connectionIdRef.current !== currentConnectionId) return;
 
-        } catch {
-            connectionStateRef.current = 'disconnected';
-        }
+                    connectionStateRef.current = 'disconnected';
+                    setIsConnected(false);
+                    onCloseRef.current?.();
+
+                    // Clear ping interval
+                    if (pingIntervalRef.current) {
+                        clearInterval(pingIntervalRef.current);
+                        pingIntervalRef.current = null;
+                    }
+
+                    // Schedule reconnection with backoff (if still mounted and enabled)
+                    if (enabled && mountedRef.current) {
+                        const delay = getReconnectDelay();
+                        reconnectAttempts.current++;
+
+                        reconnectTimeoutRef.current = setTimeout(() => {
+                            if (mountedRef.current) {
+                                connect();
+                            }
+                        }, delay);
+                    }
+                };
+
+                ws.onerror = (error) => {
+                    // Verify this is still the current connection
+                    if (connectionIdRef.current !== currentConnectionId) return;
+                    onErrorRef.current?.(error);
+                };
+
+            } catch {
+                connectionStateRef.current = 'disconnected';
+            }
+        });
     }, [getWsUrl, enabled, clearTimers, getReconnectDelay]);
 
     // Manual reconnect function (resets attempt counter)
@@ -10,12 +10,6 @@ interface ApiResponse<T> {
 }
 
 class ApiClient {
-    // Helper to get raw token for WS (returns null as we rely on cookies now)
-    // Kept for compatibility but returns null so WS uses cookie fallback
-    getToken(): string | null {
-        return null;
-    }
-
     private async request<T>(
         endpoint: string,
         options: RequestInit = {},
@@ -90,6 +84,11 @@ class ApiClient {
         return this.request<any>('/auth/me');
     }
 
+    // Single-use ticket for opening /ws?ticket=, so the access token never appears in a URL
+    async getWSTicket() {
+        return this.request<{ ticket: string; expires_in: number }>('/auth/ws-ticket', { method: 'POST' });
+    }
+
     async changePassword(currentPassword: string, newPassword: string) {
         return this.request<{ message: string }>('/auth/change-password', {
             method: 'POST',
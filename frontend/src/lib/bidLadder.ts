@@ -0,0 +1,31 @@
+// The server's default ladder, used until the auction state says otherwise
+export const DEFAULT_BID_LADDER = [
+  2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000,
+  12000, 14000, 16000, 18000, 20000,
+  24000, 28000, 32000, 36000, 40000, 45000, 50000,
+];
+
+// The ladder and max bid from the auction state (or an auction:bid-ladder broadcast)
+export function bidLadderFrom(state?: { bid_ladder?: number[]; max_bid?: number } | null) {
+  const ladder = state?.bid_ladder?.length ? state.bid_ladder : DEFAULT_BID_LADDER;
+  const maxBid = state?.max_bid || ladder[ladder.length - 1];
+  return { ladder, maxBid };
+}
+
+// The next valid bid: the first rung at or above the base price when nobody has bid,
+// otherwise the first rung above the current bid. At the top of the ladder the max bid
+// may be matched (the tie is broken by the host).
+export function nextBidAmount(ladder: number[], maxBid: number, currentBid: number, basePrice: number, hasBidder: boolean) {
+  for (const amount of ladder) {
+    if (hasBidder ? amount > currentBid : amount >= basePrice) return amount;
+  }
+  return hasBidder ? maxBid : basePrice;
+}
+
+// Up to count valid bids from the next one up
+export function upcomingBidAmounts(ladder: number[], maxBid: number, currentBid: number, hasBidder: boolean, count = 3) {
+  const upcoming = ladder
+    .filter(amount => (hasBidder ? amount > currentBid : amount >= currentBid))
+    .slice(0, count);
+  return upcoming.length > 0 ? upcoming : [maxBid];
+}